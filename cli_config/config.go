@@ -1,12 +1,14 @@
 package cli_config
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
 	"gopkg.in/yaml.v3"
 	"os"
 	"path/filepath"
+	"reflect"
 	"text/template"
 )
 
@@ -24,6 +26,12 @@ type Config struct {
 	RouterFolderPath            string `yaml:"router_folder_path"`
 	MiddlewaresFolderPath       string `yaml:"middlewares_folder_path"`
 	AuthFolderPath              string `yaml:"auth_folder_path"`
+	GrpcFolderPath              string `yaml:"grpc_folder_path"`      // path for folder where gRPC server and proto files are located
+	AppErrorsFolderPath         string `yaml:"apperrors_folder_path"` // path for folder where domain errors are located
+	KeysFolderPath              string `yaml:"keys_folder_path"`      // path for folder where JWT signing keys are located
+	TelemetryFolderPath         string `yaml:"telemetry_folder_path"` // path for folder where tracing setup is located
+	AppFolderPath               string `yaml:"app_folder_path"`       // path for folder where the lifecycle manager is located
+	ConfigFolderPath            string `yaml:"config_folder_path"`    // path for folder where the typed configuration is located
 }
 
 var CliConfig *Config
@@ -169,7 +177,54 @@ func LoadConfig() error {
 		}
 	}
 
-	return yaml.Unmarshal(data, &CliConfig)
+	err = yaml.Unmarshal(data, &CliConfig)
+	if err != nil {
+		return err
+	}
+
+	return fillMissingConfigValues()
+}
+
+// fillMissingConfigValues populates config values which are missing from the user's config file
+// with the defaults defined in the config template.
+//
+// Config files created by older goblin versions don't contain keys for newly introduced folders,
+// so without this step those folders would resolve to the project root.
+func fillMissingConfigValues() error {
+	if CliConfig == nil {
+		return nil
+	}
+
+	funcMap := template.FuncMap{
+		"GetProjectName": utils.GetProjectName,
+	}
+
+	tmpl, err := template.New("cli_config.tmpl").Funcs(funcMap).ParseFS(templates.Files, "cli_config.tmpl")
+	if err != nil {
+		return err
+	}
+
+	var defaultConfigYaml bytes.Buffer
+	err = tmpl.Execute(&defaultConfigYaml, nil)
+	if err != nil {
+		return err
+	}
+
+	var defaultConfig Config
+	err = yaml.Unmarshal(defaultConfigYaml.Bytes(), &defaultConfig)
+	if err != nil {
+		return err
+	}
+
+	currentValues := reflect.ValueOf(CliConfig).Elem()
+	defaultValues := reflect.ValueOf(defaultConfig)
+	for i := 0; i < currentValues.NumField(); i++ {
+		if currentValues.Field(i).Kind() == reflect.String && currentValues.Field(i).String() == "" {
+			currentValues.Field(i).SetString(defaultValues.Field(i).String())
+		}
+	}
+
+	return nil
 }
 
 // PrintConfigMap dynamically prints cli_config map as key: value pairs
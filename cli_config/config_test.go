@@ -0,0 +1,128 @@
+package cli_config
+
+import (
+	"os"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// useConfig makes an empty module the working directory, the project name of the defaults is read from its go.mod,
+// and loads configYaml into CliConfig
+func useConfig(t *testing.T, configYaml string) {
+	t.Helper()
+
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("go.mod", []byte("module example.com/app\n\ngo 1.24\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	previousConfig := CliConfig
+	t.Cleanup(func() { CliConfig = previousConfig })
+
+	CliConfig = nil
+	if err := yaml.Unmarshal([]byte(configYaml), &CliConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFillMissingConfigValuesAddsNewFolders(t *testing.T) {
+	// a config file written before the grpc, telemetry and config folders were introduced
+	useConfig(t, `
+models_folder_path: models
+services_folder_path: internal/services
+workers_folder_path: background/workers
+project_name: example.com/app
+`)
+
+	if err := fillMissingConfigValues(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, field := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "grpc_folder_path", got: CliConfig.GrpcFolderPath, want: "grpc"},
+		{name: "telemetry_folder_path", got: CliConfig.TelemetryFolderPath, want: "telemetry"},
+		{name: "config_folder_path", got: CliConfig.ConfigFolderPath, want: "config"},
+		{name: "jobs_folder_path", got: CliConfig.JobsFolderPath, want: "jobs"},
+	} {
+		if field.got != field.want {
+			t.Errorf("%s = %q, want the default %q", field.name, field.got, field.want)
+		}
+	}
+}
+
+func TestFillMissingConfigValuesKeepsConfiguredValues(t *testing.T) {
+	useConfig(t, `
+services_folder_path: internal/services
+workers_folder_path: background/workers
+project_name: github.com/acme/api
+`)
+
+	if err := fillMissingConfigValues(); err != nil {
+		t.Fatal(err)
+	}
+
+	if CliConfig.ServicesFolderPath != "internal/services" {
+		t.Errorf("services_folder_path = %q, want the configured internal/services", CliConfig.ServicesFolderPath)
+	}
+	if CliConfig.WorkersFolderPath != "background/workers" {
+		t.Errorf("workers_folder_path = %q, want the configured background/workers", CliConfig.WorkersFolderPath)
+	}
+	if CliConfig.ProjectName != "github.com/acme/api" {
+		t.Errorf("project_name = %q, want the configured github.com/acme/api", CliConfig.ProjectName)
+	}
+}
+
+func TestFillMissingConfigValuesReadsProjectNameFromGoMod(t *testing.T) {
+	useConfig(t, `
+models_folder_path: models
+`)
+
+	if err := fillMissingConfigValues(); err != nil {
+		t.Fatal(err)
+	}
+
+	if CliConfig.ProjectName != "example.com/app" {
+		t.Errorf("project_name = %q, want example.com/app of go.mod", CliConfig.ProjectName)
+	}
+}
+
+func TestFillMissingConfigValuesFillsEveryField(t *testing.T) {
+	useConfig(t, `{}`)
+
+	if err := fillMissingConfigValues(); err != nil {
+		t.Fatal(err)
+	}
+
+	// every field of Config has a default in cli_config.tmpl, a field added without one resolves to the project root
+	var fields map[string]string
+	data, err := yaml.Marshal(CliConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = yaml.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range fields {
+		if value == "" {
+			t.Errorf("%s has no default in cli_config.tmpl", name)
+		}
+	}
+}
+
+func TestFillMissingConfigValuesWithoutConfig(t *testing.T) {
+	previousConfig := CliConfig
+	t.Cleanup(func() { CliConfig = previousConfig })
+	CliConfig = nil
+
+	if err := fillMissingConfigValues(); err != nil {
+		t.Fatal(err)
+	}
+	if CliConfig != nil {
+		t.Error("a missing config is created, it is expected to be left to LoadConfig")
+	}
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/commands/model/flags/user"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/auth_utils"
+	"github.com/davidh16/goblin/utils/router_utils"
+	"github.com/spf13/cobra"
+	"path"
+)
+
+var AuthCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Generate register, login, refresh and logout endpoints for the user model",
+	Run: func(cmd *cobra.Command, args []string) {
+		authCmdHandler()
+	},
+}
+
+func authCmdHandler() {
+
+	if !utils.FileExists(path.Join(cli_config.CliConfig.RepositoriesFolderPath, "central_repo.go")) {
+		utils.HandleError(errors.New("central repository does not exist, run goblin repo --central-repo first"))
+	}
+
+	if !utils.FileExists(path.Join(cli_config.CliConfig.ServicesFolderPath, "central_service.go")) {
+		utils.HandleError(errors.New("central service does not exist, run goblin service --central-service first"))
+	}
+
+	if !utils.FileExists(path.Join(cli_config.CliConfig.ControllersFolderPath, "central_controller.go")) {
+		utils.HandleError(errors.New("central controller does not exist, run goblin controller --central-controller first"))
+	}
+
+	if !utils.FileExists(path.Join(cli_config.CliConfig.ModelsFolderPath, "user.go")) {
+		var generateUserModel bool
+		if err := survey.AskOne(&survey.Confirm{
+			Message: "User model does not exist, do you want to generate it now ?",
+			Default: true,
+		}, &generateUserModel); err != nil {
+			utils.HandleError(err)
+		}
+
+		if !generateUserModel {
+			fmt.Println("🛑 Auth is built on top of the user model, run goblin model --user first")
+			return
+		}
+
+		user.GenerateUserModel()
+	}
+
+	var signingMethod string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Which signing method do you want to sign access tokens with?",
+		Options: auth_utils.SigningMethodOptions,
+		Default: auth_utils.SigningMethodHS512,
+		Description: func(value string, index int) string {
+			if value == auth_utils.SigningMethodHS512 {
+				return "shared JWT_SECRET"
+			}
+			return "rotatable keypairs, public keys served on /.well-known/jwks.json"
+		},
+	}, &signingMethod); err != nil {
+		utils.HandleError(err)
+	}
+
+	err := auth_utils.GenerateAuth(signingMethod)
+	if err != nil {
+		utils.HandleError(err, "Failed to generate auth")
+	}
+
+	var regenerateRouter bool
+	if err = survey.AskOne(&survey.Confirm{
+		Message: "Do you want to regenerate router.go so the auth routes and the protected group are registered? (router.go will be overwritten)",
+		Default: true,
+	}, &regenerateRouter); err != nil {
+		utils.HandleError(err)
+	}
+
+	if regenerateRouter {
+		err = router_utils.GenerateRouter(router_utils.DetectRouterData())
+		if err != nil {
+			utils.HandleError(err, "Error generating router.go file")
+		}
+	}
+
+	fmt.Println("✅ Auth generated successfully.")
+	return
+}
@@ -0,0 +1,33 @@
+package rotate_keys
+
+import (
+	"fmt"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/auth_utils"
+	"github.com/spf13/cobra"
+)
+
+var RotateKeysCmd = &cobra.Command{
+	Use:   "rotate-keys",
+	Short: "Add a new JWT signing key, tokens signed with older keys stay valid",
+	Run: func(cmd *cobra.Command, args []string) {
+		rotateKeysCmdHandler()
+	},
+}
+
+func rotateKeysCmdHandler() {
+	kid, err := auth_utils.RotateKeys()
+	if err != nil {
+		utils.HandleError(err, "Failed to rotate signing keys")
+	}
+
+	kids, err := auth_utils.ListSigningKeys()
+	if err != nil {
+		utils.HandleError(err)
+	}
+
+	fmt.Printf("✅ Signing key %s generated successfully, the app publishes it within JWT_KEYS_RELOAD_INTERVAL (or on SIGHUP) and signs new tokens with it once it is JWT_KEY_ACTIVATION_DELAY old.\n", kid)
+	if len(kids) > 1 {
+		fmt.Printf("⚠️ %d older keys are still used to verify tokens, delete them once tokens signed with them have expired.\n", len(kids)-1)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/davidh16/goblin/cli_config"
 	"github.com/davidh16/goblin/utils"
 	"github.com/davidh16/goblin/utils/database_utils"
+	"github.com/davidh16/goblin/utils/initialize_utils"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"os"
@@ -144,6 +145,24 @@ func databaseCmdHandler() {
 		utils.HandleError(err, fmt.Sprintf("Error writing environment file %s", envFilePath))
 	}
 
+	// connections are registered with the lifecycle manager by main.go, so they are checked by /readyz and closed on shutdown
+	if utils.FileExists(path.Join(workingDirectory, "main.go")) {
+		var regenerateMain bool
+		if err = survey.AskOne(&survey.Confirm{
+			Message: "Do you want to regenerate main.go so the databases are connected on startup and closed on shutdown? (main.go will be overwritten)",
+			Default: true,
+		}, &regenerateMain); err != nil {
+			utils.HandleError(err)
+		}
+
+		if regenerateMain {
+			err = initialize_utils.GenerateMainFile(initialize_utils.DetectMainData())
+			if err != nil {
+				utils.HandleError(err, "Error generating main.go file")
+			}
+		}
+	}
+
 	return
 }
 
@@ -0,0 +1,13 @@
+package deploy
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var DeployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Generate deployment manifests for the project",
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/deploy_utils"
+	"github.com/spf13/cobra"
+)
+
+var K8sCmd = &cobra.Command{
+	Use:   "k8s",
+	Short: "Generate Kubernetes manifests with the probes, config, secrets and migration job of the project",
+	Run: func(cmd *cobra.Command, args []string) {
+		k8sCmdHandler()
+	},
+}
+
+func k8sCmdHandler() {
+	if !utils.FileExists("go.mod") {
+		utils.HandleError(errors.New("go.mod does not exist, run goblin initialize first"))
+	}
+
+	if utils.FileExists(deploy_utils.K8sFolderPath) {
+		var overwrite bool
+		if err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("%s already exists, do you want to overwrite the manifests?", deploy_utils.K8sFolderPath),
+			Default: false,
+		}, &overwrite); err != nil {
+			utils.HandleError(err)
+		}
+
+		if !overwrite {
+			return
+		}
+	}
+
+	k8sData, err := deploy_utils.DetectK8sData()
+	if err != nil {
+		utils.HandleError(err, "Failed to inspect the project")
+	}
+
+	err = deploy_utils.GenerateK8sManifests(k8sData)
+	if err != nil {
+		utils.HandleError(err, "Failed to generate Kubernetes manifests")
+	}
+
+	fmt.Printf("ℹ️ Fill in %s/%s, set the image of the deployments and apply them with kubectl apply -k %s.\n", deploy_utils.K8sFolderPath, deploy_utils.K8sSecretFileName, deploy_utils.K8sFolderPath)
+}
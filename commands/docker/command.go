@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/docker_utils"
+	"github.com/spf13/cobra"
+)
+
+var DockerCmd = &cobra.Command{
+	Use:   "docker",
+	Short: "Generate a Dockerfile and a docker-compose.yml with the databases and workers of the project",
+	Run: func(cmd *cobra.Command, args []string) {
+		dockerCmdHandler()
+	},
+}
+
+func dockerCmdHandler() {
+	if !utils.FileExists("go.mod") {
+		utils.HandleError(errors.New("go.mod does not exist, run goblin initialize first"))
+	}
+
+	dockerData, err := docker_utils.DetectDockerData()
+	if err != nil {
+		utils.HandleError(err, "Failed to inspect the project")
+	}
+
+	var fileNames []string
+	for _, fileName := range docker_utils.DockerFiles {
+		if utils.FileExists(fileName) {
+			var overwrite bool
+			if err = survey.AskOne(&survey.Confirm{
+				Message: fmt.Sprintf("%s already exists, do you want to overwrite it?", fileName),
+				Default: false,
+			}, &overwrite); err != nil {
+				utils.HandleError(err)
+			}
+
+			if !overwrite {
+				continue
+			}
+		}
+		fileNames = append(fileNames, fileName)
+	}
+
+	err = docker_utils.GenerateDockerFiles(fileNames, dockerData)
+	if err != nil {
+		utils.HandleError(err, "Failed to generate docker files")
+	}
+
+	fmt.Println("ℹ️ Run docker compose up --build to start the stack, ports and credentials are read from .env.")
+}
@@ -0,0 +1,54 @@
+package check
+
+import (
+	"fmt"
+	"github.com/davidh16/goblin/commands/env"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/env_utils"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var CheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report variables the project reads but the env file lacks, and the ones it sets but nothing reads",
+	Run: func(cmd *cobra.Command, args []string) {
+		checkCmdHandler()
+	},
+}
+
+func checkCmdHandler() {
+	report, err := env_utils.CheckEnvFile(env.ProfileFlag)
+	if err != nil {
+		utils.HandleError(err, "Failed to check environment variables")
+	}
+
+	if len(report.Missing) > 0 {
+		fmt.Printf("❌ Missing from %s:\n", report.EnvFileName)
+		for _, usage := range report.Missing {
+			fmt.Printf("   %s (read in %s)\n", usage.Key, strings.Join(usage.Positions, ", "))
+		}
+	}
+
+	if len(report.Unused) > 0 {
+		fmt.Printf("⚠️ Set in %s but not read by the project:\n", report.EnvFileName)
+		for _, key := range report.Unused {
+			fmt.Printf("   %s\n", key)
+		}
+	}
+
+	if len(report.Dynamic) > 0 {
+		fmt.Println("ℹ️ Read by names built at runtime, matching keys are not reported as unused:")
+		for _, usage := range report.Dynamic {
+			fmt.Printf("   %s (read in %s)\n", usage.Key, strings.Join(usage.Positions, ", "))
+		}
+	}
+
+	if len(report.Missing) > 0 {
+		fmt.Println("🛑 Run goblin env to add the missing variables.")
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ %s sets every variable read by the project.\n", report.EnvFileName)
+}
@@ -0,0 +1,37 @@
+package env
+
+import (
+	"fmt"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/config_utils"
+	"github.com/davidh16/goblin/utils/env_utils"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+var ProfileFlag string
+
+var EnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Add the variables read by the project to .env, or to .env.<profile> with --profile",
+	Run: func(cmd *cobra.Command, args []string) {
+		envCmdHandler()
+	},
+}
+
+func envCmdHandler() {
+	addedKeys, err := env_utils.SyncEnvFile(ProfileFlag)
+	if err != nil {
+		utils.HandleError(err, fmt.Sprintf("Failed to update %s", env_utils.EnvFilePath(ProfileFlag)))
+	}
+
+	if len(addedKeys) == 0 {
+		fmt.Printf("✅ %s already sets every variable read by the project.\n", env_utils.EnvFilePath(ProfileFlag))
+		return
+	}
+
+	fmt.Printf("✅ %s updated successfully, added %s.\n", env_utils.EnvFilePath(ProfileFlag), strings.Join(addedKeys, ", "))
+	if ProfileFlag != "" {
+		fmt.Printf("ℹ️ Run the app with %s=%s to load it on top of .env.\n", config_utils.ProfileVariable, ProfileFlag)
+	}
+}
@@ -0,0 +1,41 @@
+package decrypt
+
+import (
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/davidh16/goblin/commands/env"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/env_utils"
+	"github.com/spf13/cobra"
+)
+
+var DecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Decrypt .env.enc, or .env.<profile>.enc with --profile, into the plain env file",
+	Run: func(cmd *cobra.Command, args []string) {
+		decryptCmdHandler()
+	},
+}
+
+func decryptCmdHandler() {
+	if utils.FileExists(env_utils.EnvFilePath(env.ProfileFlag)) {
+		var overwrite bool
+		if err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("%s already exists, do you want to overwrite it with the decrypted values?", env_utils.EnvFilePath(env.ProfileFlag)),
+			Default: false,
+		}, &overwrite); err != nil {
+			utils.HandleError(err)
+		}
+
+		if !overwrite {
+			return
+		}
+	}
+
+	err := env_utils.DecryptEnvFile(env.ProfileFlag)
+	if err != nil {
+		utils.HandleError(err, fmt.Sprintf("Failed to decrypt %s", env_utils.EncryptedEnvFilePath(env.ProfileFlag)))
+	}
+
+	fmt.Printf("✅ %s decrypted into %s successfully.\n", env_utils.EncryptedEnvFilePath(env.ProfileFlag), env_utils.EnvFilePath(env.ProfileFlag))
+}
@@ -0,0 +1,26 @@
+package edit
+
+import (
+	"fmt"
+	"github.com/davidh16/goblin/commands/env"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/env_utils"
+	"github.com/spf13/cobra"
+)
+
+var EditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit .env.enc, or .env.<profile>.enc with --profile, in $EDITOR without leaving decrypted values in the project",
+	Run: func(cmd *cobra.Command, args []string) {
+		editCmdHandler()
+	},
+}
+
+func editCmdHandler() {
+	err := env_utils.EditEnvFile(env.ProfileFlag)
+	if err != nil {
+		utils.HandleError(err, fmt.Sprintf("Failed to edit %s", env_utils.EncryptedEnvFilePath(env.ProfileFlag)))
+	}
+
+	fmt.Printf("✅ %s updated successfully.\n", env_utils.EncryptedEnvFilePath(env.ProfileFlag))
+}
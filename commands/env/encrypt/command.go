@@ -0,0 +1,28 @@
+package encrypt
+
+import (
+	"fmt"
+	"github.com/davidh16/goblin/commands/env"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/config_utils"
+	"github.com/davidh16/goblin/utils/env_utils"
+	"github.com/spf13/cobra"
+)
+
+var EncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt .env, or .env.<profile> with --profile, into a .enc file which can be committed",
+	Run: func(cmd *cobra.Command, args []string) {
+		encryptCmdHandler()
+	},
+}
+
+func encryptCmdHandler() {
+	err := env_utils.EncryptEnvFile(env.ProfileFlag)
+	if err != nil {
+		utils.HandleError(err, fmt.Sprintf("Failed to encrypt %s", env_utils.EnvFilePath(env.ProfileFlag)))
+	}
+
+	fmt.Printf("✅ %s encrypted into %s successfully, it can be committed.\n", env_utils.EnvFilePath(env.ProfileFlag), env_utils.EncryptedEnvFilePath(env.ProfileFlag))
+	fmt.Printf("ℹ️ Share %s securely, the app decrypts the file on startup with it or with %s.\n", config_utils.EncryptionKeyFileName, config_utils.EncryptionKeyVariable)
+}
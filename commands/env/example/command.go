@@ -0,0 +1,25 @@
+package example
+
+import (
+	"fmt"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/env_utils"
+	"github.com/spf13/cobra"
+)
+
+var ExampleCmd = &cobra.Command{
+	Use:   "example",
+	Short: "Generate .env.example with every variable the project reads, commented by component",
+	Run: func(cmd *cobra.Command, args []string) {
+		exampleCmdHandler()
+	},
+}
+
+func exampleCmdHandler() {
+	err := env_utils.GenerateEnvExample()
+	if err != nil {
+		utils.HandleError(err, "Failed to generate .env.example")
+	}
+
+	fmt.Println("✅ .env.example generated successfully.")
+}
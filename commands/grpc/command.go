@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/grpc_utils"
+	"github.com/davidh16/goblin/utils/initialize_utils"
+	"github.com/davidh16/goblin/utils/service_utils"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+	"strings"
+)
+
+var GrpcCmd = &cobra.Command{
+	Use:   "grpc",
+	Short: "Generate JSON over gRPC transport layer for existing services",
+	Long: `Generate gRPC servers and clients for existing services, delegating to the central service.
+
+The transport is JSON over gRPC: messages are encoded with a json codec and have to be sent with the
+application/grpc+json content type. No protoc generated code is used, the .proto files written to the
+proto folder only describe the JSON messages and can not be used to generate protobuf clients.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		grpcCmdHandler()
+	},
+}
+
+func grpcCmdHandler() {
+
+	if !utils.FileExists(path.Join(cli_config.CliConfig.ServicesFolderPath, "central_service.go")) {
+		utils.HandleError(errors.New("central service does not exist, run goblin service --central-service first"))
+	}
+
+	existingServices, err := service_utils.ListExistingServices()
+	if err != nil {
+		utils.HandleError(err, "Unable to list existing services")
+	}
+
+	if len(existingServices) == 0 {
+		fmt.Println("🛑 There are no services to expose, generate a service first")
+		return
+	}
+
+	existingServicesMap := make(map[string]service_utils.ServiceData)
+	for _, existingService := range existingServices {
+		existingServicesMap[existingService.ServiceFullName] = existingService
+	}
+
+	var selectedServices []string
+	err = survey.AskOne(&survey.MultiSelect{
+		Message: "Which services do you want to expose over gRPC?",
+		Options: utils.Keys(existingServicesMap),
+	}, &selectedServices, survey.WithValidator(survey.Required))
+	if err != nil {
+		utils.HandleError(err)
+	}
+
+	var grpcPort string
+	if err = survey.AskOne(&survey.Input{
+		Message: "Please type in gRPC server port you want to use :",
+		Default: "50051",
+	}, &grpcPort); err != nil {
+		utils.HandleError(err)
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		utils.HandleError(err, "Unable to get working directory")
+	}
+	envFile, err := os.OpenFile(path.Join(workingDirectory, ".env"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		utils.HandleError(err)
+	}
+	defer envFile.Close()
+
+	err = utils.WriteToEnvFile(envFile, map[string]string{
+		"GRPC_BIND_PORT":    grpcPort,
+		"GRPC_BIND_ADDRESS": "0.0.0.0",
+	})
+	if err != nil {
+		utils.HandleError(err, "Failed to write to env file")
+	}
+
+	for _, selectedService := range selectedServices {
+		grpcServiceData, err := grpc_utils.ParseServiceInterface(existingServicesMap[selectedService])
+		if err != nil {
+			utils.HandleError(err)
+		}
+
+		if len(grpcServiceData.SkippedMethods) > 0 {
+			fmt.Printf("⚠️ %s methods %s use types that can not be described in a .proto file and were skipped\n", grpcServiceData.ServiceFullName, strings.Join(grpcServiceData.SkippedMethods, ", "))
+		}
+
+		err = grpc_utils.GenerateServiceProto(grpcServiceData)
+		if err != nil {
+			utils.HandleError(err, fmt.Sprintf("Failed to generate %s proto file", grpcServiceData.ServiceFullName))
+		}
+
+		err = grpc_utils.GenerateServiceServer(grpcServiceData)
+		if err != nil {
+			utils.HandleError(err, fmt.Sprintf("Failed to generate %s gRPC server", grpcServiceData.ServiceFullName))
+		}
+	}
+
+	err = grpc_utils.GenerateModelsProto()
+	if err != nil {
+		utils.HandleError(err, "Failed to generate models proto file")
+	}
+
+	err = grpc_utils.GenerateServer()
+	if err != nil {
+		utils.HandleError(err, "Failed to generate gRPC server")
+	}
+
+	var regenerateMain bool
+	if err = survey.AskOne(&survey.Confirm{
+		Message: "Do you want to regenerate main.go so the gRPC server is started next to the HTTP server? (main.go will be overwritten)",
+		Default: true,
+	}, &regenerateMain); err != nil {
+		utils.HandleError(err)
+	}
+
+	if regenerateMain {
+		err = initialize_utils.GenerateMainFile(initialize_utils.DetectMainData())
+		if err != nil {
+			utils.HandleError(err, "Error generating main.go file")
+		}
+	}
+
+	fmt.Println("✅ gRPC transport layer generated successfully.")
+	return
+}
@@ -2,8 +2,6 @@ package initialize
 
 import (
 	"github.com/AlecAivazis/survey/v2"
-	"github.com/davidh16/goblin/cli_config"
-	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
 	"github.com/davidh16/goblin/utils/database_utils"
 	"github.com/davidh16/goblin/utils/initialize_utils"
@@ -12,8 +10,6 @@ import (
 	"github.com/spf13/cobra"
 	"os"
 	"path"
-	"strings"
-	"text/template"
 )
 
 var InitializeCmd = &cobra.Command{
@@ -138,66 +134,14 @@ func initCmdHandler() {
 	}
 
 	// execute main
-	tmpl, err := template.ParseFS(templates.Files, initialize_utils.MainTemplatePath)
-	if err != nil {
-		utils.HandleError(err, "Error parsing template")
-	}
-
-	f, err := os.Create(path.Join(workingDirectory, "main.go"))
-	if err != nil {
-		utils.HandleError(err, "Unable to create main.go file")
-	}
-	defer f.Close()
-
-	templateData := struct {
-		RouterPackage       string
-		RouterPackageImport string
-
-		ControllersPackageImport string
-		ControllersPackage       string
-
-		ServicesPackageImport string
-		ServicesPackage       string
-
-		RepositoriesPackageImport string
-		RepositoriesPackage       string
-
-		DatabasesPackageImport string
-		DatabasesPackage       string
-
-		ImplementCentralRepository bool
-		ImplementCentralService    bool
-
-		LoggerImplemented   bool
-		LoggerPackage       string
-		LoggerPackageImport string
-	}{
-		RouterPackage:       strings.Split(cli_config.CliConfig.RouterFolderPath, "/")[len(strings.Split(cli_config.CliConfig.RouterFolderPath, "/"))-1],
-		RouterPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.RouterFolderPath),
-
-		ControllersPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ControllersFolderPath),
-		ControllersPackage:       strings.Split(cli_config.CliConfig.ControllersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ControllersFolderPath, "/"))-1],
-
-		ServicesPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ServicesFolderPath),
-		ServicesPackage:       strings.Split(cli_config.CliConfig.ServicesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ServicesFolderPath, "/"))-1],
-
-		RepositoriesPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.RepositoriesFolderPath),
-		RepositoriesPackage:       strings.Split(cli_config.CliConfig.RepositoriesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.RepositoriesFolderPath, "/"))-1],
-
-		DatabasesPackage:       strings.Split(cli_config.CliConfig.DatabaseInstancesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.DatabaseInstancesFolderPath, "/"))-1],
-		DatabasesPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.DatabaseInstancesFolderPath),
-
-		ImplementCentralRepository: initData.ImplementCentralRepository,
-		ImplementCentralService:    initData.ImplementCentralService,
-
-		LoggerImplemented:   routerData.LoggingMiddleware,
-		LoggerPackage:       strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
-		LoggerPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.LoggerFolderPath),
-	}
+	mainData := initialize_utils.DetectMainData()
+	mainData.ImplementCentralRepository = initData.ImplementCentralRepository
+	mainData.ImplementCentralService = initData.ImplementCentralService
+	mainData.LoggerImplemented = routerData.LoggingMiddleware
 
-	err = tmpl.Execute(f, templateData)
+	err = initialize_utils.GenerateMainFile(mainData)
 	if err != nil {
-		utils.HandleError(err, "Error executing template")
+		utils.HandleError(err, "Error generating main.go file")
 	}
 
 	return
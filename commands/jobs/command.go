@@ -0,0 +1,17 @@
+package jobs
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var JobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "List, show, retry and purge the failed jobs saved by the jobs manager",
+	Long: `List, show, retry and purge the failed jobs saved by the jobs manager.
+
+The commands run the failed jobs admin generated by goblin workerize with go run in the project,
+so they use its database and Redis. A deployed binary takes the same arguments, i.e <binary> jobs list.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
@@ -0,0 +1,27 @@
+package list
+
+import (
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/jobs_utils"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+var FilterFlags jobs_utils.FilterFlags
+var LimitFlag int
+
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List failed jobs by type and by the time they failed at, the ones which failed last first",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		listCmdHandler()
+	},
+}
+
+func listCmdHandler() {
+	args := append([]string{"list", "--limit", strconv.Itoa(LimitFlag)}, FilterFlags.Args()...)
+	if err := jobs_utils.RunJobsAdmin(args...); err != nil {
+		utils.HandleError(err, "Failed to list failed jobs")
+	}
+}
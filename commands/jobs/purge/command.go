@@ -0,0 +1,34 @@
+package purge
+
+import (
+	"errors"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/jobs_utils"
+	"github.com/spf13/cobra"
+)
+
+var FilterFlags jobs_utils.FilterFlags
+var AllFlag bool
+
+var PurgeCmd = &cobra.Command{
+	Use:   "purge [uuid...]",
+	Short: "Delete failed jobs, the given ones or all the ones matching the filter flags with --all",
+	Run: func(cmd *cobra.Command, args []string) {
+		purgeCmdHandler(args)
+	},
+}
+
+func purgeCmdHandler(uuids []string) {
+	if AllFlag == (len(uuids) > 0) {
+		utils.HandleError(errors.New("pass the uuids of the jobs to purge, or --all to purge every job matching the filter flags"))
+	}
+
+	args := []string{"purge"}
+	if AllFlag {
+		args = append(append(args, "--all"), FilterFlags.Args()...)
+	}
+
+	if err := jobs_utils.RunJobsAdmin(append(args, uuids...)...); err != nil {
+		utils.HandleError(err, "Failed to purge failed jobs")
+	}
+}
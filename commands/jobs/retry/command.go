@@ -0,0 +1,34 @@
+package retry
+
+import (
+	"errors"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/jobs_utils"
+	"github.com/spf13/cobra"
+)
+
+var FilterFlags jobs_utils.FilterFlags
+var AllFlag bool
+
+var RetryCmd = &cobra.Command{
+	Use:   "retry [uuid...]",
+	Short: "Push failed jobs back to their queue with their retries reset, the given ones or all the ones matching the filter flags with --all",
+	Run: func(cmd *cobra.Command, args []string) {
+		retryCmdHandler(args)
+	},
+}
+
+func retryCmdHandler(uuids []string) {
+	if AllFlag == (len(uuids) > 0) {
+		utils.HandleError(errors.New("pass the uuids of the jobs to retry, or --all to retry every job matching the filter flags"))
+	}
+
+	args := []string{"retry"}
+	if AllFlag {
+		args = append(append(args, "--all"), FilterFlags.Args()...)
+	}
+
+	if err := jobs_utils.RunJobsAdmin(append(args, uuids...)...); err != nil {
+		utils.HandleError(err, "Failed to retry failed jobs")
+	}
+}
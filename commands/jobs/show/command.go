@@ -0,0 +1,22 @@
+package show
+
+import (
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/jobs_utils"
+	"github.com/spf13/cobra"
+)
+
+var ShowCmd = &cobra.Command{
+	Use:   "show <uuid>",
+	Short: "Show the metadata and the error of a failed job",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		showCmdHandler(args[0])
+	},
+}
+
+func showCmdHandler(uuid string) {
+	if err := jobs_utils.RunJobsAdmin("show", uuid); err != nil {
+		utils.HandleError(err, "Failed to show failed job")
+	}
+}
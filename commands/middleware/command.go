@@ -1,10 +1,15 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/api_key_utils"
+	"github.com/davidh16/goblin/utils/auth_utils"
+	"github.com/davidh16/goblin/utils/initialize_utils"
 	"github.com/davidh16/goblin/utils/middleware_utils"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"os"
 )
@@ -48,12 +53,76 @@ func middlewareCmdHandler() {
 		utils.HandleError(err)
 	}
 
+	rbacSelected := lo.Contains(selectedMiddlewares, "RbacMiddleware")
+	if rbacSelected && !auth_utils.AuthImplemented() {
+		utils.HandleError(errors.New("RbacMiddleware checks the roles carried by access tokens, run goblin auth first"))
+	}
+
+	// api keys are looked up through the generated service, so it has to exist before the middleware using it
+	if lo.Contains(selectedMiddlewares, "ApiKeyMiddleware") {
+		err = api_key_utils.GenerateApiKeys()
+		if err != nil {
+			utils.HandleError(err, "Failed to generate api keys")
+		}
+	}
+
 	// generate
 	err = middleware_utils.GenerateMiddlewares(selectedMiddlewares)
 	if err != nil {
 		utils.HandleError(err)
 	}
 
+	if rbacSelected {
+		err = auth_utils.GenerateRbac()
+		if err != nil {
+			utils.HandleError(err, "Failed to generate roles and permissions")
+		}
+
+		var regenerateMain bool
+		if err = survey.AskOne(&survey.Confirm{
+			Message: "Do you want to regenerate main.go so the permission policy is loaded on startup? (main.go will be overwritten)",
+			Default: true,
+		}, &regenerateMain); err != nil {
+			utils.HandleError(err)
+		}
+
+		if regenerateMain {
+			err = initialize_utils.GenerateMainFile(initialize_utils.DetectMainData())
+			if err != nil {
+				utils.HandleError(err, "Error generating main.go file")
+			}
+		}
+
+		fmt.Println("✅ Annotate controller methods with // @route <METHOD> </path> and // @permission <permission> and regenerate the router to protect them.")
+	}
+
+	if lo.Contains(selectedMiddlewares, "ApiKeyMiddleware") {
+		fmt.Println("✅ Build ApiKeyMiddleware with NewApiKeyMiddleware(centralService.ApiKeyService).Build() and issue keys with ApiKeyService.Issue.")
+	}
+
+	if lo.Contains(selectedMiddlewares, "IdempotencyMiddleware") {
+		fmt.Println("✅ Build IdempotencyMiddleware with NewIdempotencyMiddleware(store).Build() using NewRedisIdempotencyStore or NewSqlIdempotencyStore, or regenerate the router to inject it.")
+	}
+
+	if lo.Contains(selectedMiddlewares, "MetricsMiddleware") {
+		var regenerateMain bool
+		if err = survey.AskOne(&survey.Confirm{
+			Message: "Do you want to regenerate main.go so /metrics is served on the admin port? (main.go will be overwritten)",
+			Default: true,
+		}, &regenerateMain); err != nil {
+			utils.HandleError(err)
+		}
+
+		if regenerateMain {
+			err = initialize_utils.GenerateMainFile(initialize_utils.DetectMainData())
+			if err != nil {
+				utils.HandleError(err, "Error generating main.go file")
+			}
+		}
+
+		fmt.Println("✅ Job metrics are recorded by orchestrators generated from now on, regenerate it with goblin workerize to record them.")
+	}
+
 	fmt.Println("✅ Keep in mind that newly implemented middlewares must be injected in router manually.")
 
 	return
@@ -0,0 +1,125 @@
+package observability
+
+import (
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/database_utils"
+	"github.com/davidh16/goblin/utils/initialize_utils"
+	"github.com/davidh16/goblin/utils/observability_utils"
+	"github.com/davidh16/goblin/utils/router_utils"
+	"github.com/davidh16/goblin/utils/workerize_utils"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+	"strings"
+)
+
+var ObservabilityCmd = &cobra.Command{
+	Use:   "observability",
+	Short: "Generate distributed tracing with OpenTelemetry",
+	Run: func(cmd *cobra.Command, args []string) {
+		observabilityCmdHandler()
+	},
+}
+
+func observabilityCmdHandler() {
+	if observability_utils.TracingImplemented() {
+		var overwrite bool
+		if err := survey.AskOne(&survey.Confirm{
+			Message: "Tracing already exists, do you want to overwrite it?",
+			Default: false,
+		}, &overwrite); err != nil {
+			utils.HandleError(err)
+		}
+
+		if !overwrite {
+			return
+		}
+	}
+
+	var tracesExporter string
+	if err := survey.AskOne(&survey.Select{
+		Message: "Where should spans be exported?\n  [otlp sends them to an OpenTelemetry collector, console prints them for local development]\n",
+		Options: observability_utils.TracesExporterOptions,
+		Default: observability_utils.TracesExporterOptions[0],
+	}, &tracesExporter); err != nil {
+		utils.HandleError(err)
+	}
+
+	err := observability_utils.GenerateTracing(tracesExporter)
+	if err != nil {
+		utils.HandleError(err, "Failed to generate tracing")
+	}
+
+	// connectors are instrumented when they are rendered, so the existing ones have to be regenerated
+	var existingDatabaseNames []string
+	for _, databaseName := range database_utils.GetSortedDatabaseOptions() {
+		databaseOption := database_utils.DatabaseNameOptionsMap[databaseName]
+		if utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, database_utils.DatabaseOptionInstanceDefaultFileNamesMap[databaseOption])) {
+			existingDatabaseNames = append(existingDatabaseNames, databaseName)
+		}
+	}
+
+	if len(existingDatabaseNames) > 0 && confirm(fmt.Sprintf("Do you want to regenerate the %s connectors so queries and commands are traced? (connectors will be overwritten)", strings.Join(existingDatabaseNames, ", "))) {
+		for _, databaseName := range existingDatabaseNames {
+			err = database_utils.InitializeDatabaseInstance(database_utils.DatabaseData{
+				DatabaseType: database_utils.DatabaseNameOptionsMap[databaseName],
+			})
+			if err != nil {
+				utils.HandleError(err, fmt.Sprintf("Error initializing %s database instance", databaseName))
+			}
+		}
+	}
+
+	if utils.FileExists(path.Join(cli_config.CliConfig.JobsFolderPath, "jobs_manager.go")) && confirm("Do you want to regenerate the jobs manager so jobs carry the trace of the request enqueuing them? (jobs_manager.go will be overwritten)") {
+		err = workerize_utils.AddTraceContextToJob()
+		if err != nil {
+			utils.HandleError(err, "Failed to add trace context to jobs")
+		}
+
+		err = workerize_utils.ImplementJobsLogic(&workerize_utils.WorkerizeData{
+			JobsManagerOverwrite: true,
+			LoggerImplemented:    utils.FileExists(path.Join(cli_config.CliConfig.LoggerFolderPath, "logger.go")),
+		})
+		if err != nil {
+			utils.HandleError(err, "Failed to generate jobs manager")
+		}
+
+		fmt.Printf("✅ Worker pools generated from now on continue the trace, existing ones can start their span with %s.StartConsumerSpan(ctx, job.TraceContext, name).\n", observability_utils.TelemetryPackage())
+	}
+
+	if utils.FileExists(path.Join(cli_config.CliConfig.RouterFolderPath, "router.go")) && confirm("Do you want to regenerate the router so requests are traced? (router.go will be overwritten)") {
+		err = router_utils.GenerateRouter(router_utils.DetectRouterData())
+		if err != nil {
+			utils.HandleError(err, "Error generating router")
+		}
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		utils.HandleError(err)
+	}
+
+	if utils.FileExists(path.Join(workingDirectory, "main.go")) && confirm("Do you want to regenerate main.go so the tracer is set up on startup? (main.go will be overwritten)") {
+		err = initialize_utils.GenerateMainFile(initialize_utils.DetectMainData())
+		if err != nil {
+			utils.HandleError(err, "Error generating main.go file")
+		}
+	}
+
+	fmt.Println("✅ Tracing generated successfully.")
+	fmt.Println("✅ Pass c.Request().Context() down to services, db.WithContext(ctx) and EnqueueJob so their spans join the request trace.")
+}
+
+func confirm(message string) bool {
+	var confirmed bool
+	if err := survey.AskOne(&survey.Confirm{
+		Message: message,
+		Default: true,
+	}, &confirmed); err != nil {
+		utils.HandleError(err)
+	}
+	return confirmed
+}
@@ -1,12 +1,16 @@
 package router
 
 import (
+	"errors"
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/davidh16/goblin/cli_config"
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/api_key_utils"
+	"github.com/davidh16/goblin/utils/auth_utils"
 	"github.com/davidh16/goblin/utils/middleware_utils"
 	"github.com/davidh16/goblin/utils/router_utils"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"path"
 )
@@ -48,6 +52,19 @@ func routerCmdHandler() {
 		utils.HandleError(err)
 	}
 
+	rbacSelected := lo.Contains(selectedMiddlewares, "RbacMiddleware")
+	if rbacSelected && !auth_utils.AuthImplemented() {
+		utils.HandleError(errors.New("RbacMiddleware checks the roles carried by access tokens, run goblin auth first"))
+	}
+
+	// api keys are looked up through the generated service, so it has to exist before the middleware using it
+	if lo.Contains(selectedMiddlewares, "ApiKeyMiddleware") {
+		err = api_key_utils.GenerateApiKeys()
+		if err != nil {
+			utils.HandleError(err, "Failed to generate api keys")
+		}
+	}
+
 	if len(selectedMiddlewares) > 0 {
 		routerData.ImplementMiddlewares = true
 		err = middleware_utils.GenerateMiddlewares(selectedMiddlewares)
@@ -55,6 +72,13 @@ func routerCmdHandler() {
 			utils.HandleError(err)
 		}
 	}
+
+	if rbacSelected {
+		err = auth_utils.GenerateRbac()
+		if err != nil {
+			utils.HandleError(err, "Failed to generate roles and permissions")
+		}
+	}
 	for _, m := range selectedMiddlewares {
 		switch m {
 		case "RecoverMiddleware":
@@ -65,9 +89,21 @@ func routerCmdHandler() {
 			routerData.RateLimiterMiddleware = true
 		case "AllowOriginMiddleware":
 			routerData.AllowOriginMiddleware = true
+		case "IdempotencyMiddleware":
+			routerData.IdempotencyMiddleware = true
+		case "RequestIDMiddleware":
+			routerData.RequestIDMiddleware = true
+		case "MetricsMiddleware":
+			routerData.MetricsMiddleware = true
 		}
 	}
 
+	// auth routes are kept whenever the auth layer has been generated
+	if auth_utils.AuthImplemented() {
+		routerData.AuthImplemented = true
+		routerData.ImplementMiddlewares = true
+	}
+
 	err = router_utils.GenerateRouter(routerData)
 	if err != nil {
 		utils.HandleError(err)
@@ -207,7 +207,7 @@ func serviceCmdHandler() {
 	case service_utils.RepoStrategyNoImplementation:
 		serviceData.RepoData = nil
 	default:
-		utils.HandleError(fmt.Errorf("invalid repo strategy: %s", serviceData.RepoStrategy))
+		utils.HandleError(fmt.Errorf("invalid repo strategy: %d", serviceData.RepoStrategy))
 	}
 
 	if serviceData.RepoStrategy == service_utils.RepoStrategyNewRepo {
@@ -4,15 +4,20 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/davidh16/goblin/commands/database"
 	central_service "github.com/davidh16/goblin/commands/service/flags/central-service"
+	"github.com/davidh16/goblin/commands/workerize/flags/cron"
 	"github.com/davidh16/goblin/commands/workerize/flags/job"
 	"github.com/davidh16/goblin/utils"
 	"github.com/davidh16/goblin/utils/database_utils"
+	"github.com/davidh16/goblin/utils/initialize_utils"
 	"github.com/davidh16/goblin/utils/logger_utils"
 	"github.com/davidh16/goblin/utils/workerize_utils"
 	"github.com/spf13/cobra"
+	"os"
+	"path"
 )
 
 var CustomJobFlag bool
+var CronFlag bool
 
 var WorkerizeCmd = &cobra.Command{
 	Use:   "workerize",
@@ -20,6 +25,8 @@ var WorkerizeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		if CustomJobFlag {
 			job.GenerateCustomJob()
+		} else if CronFlag {
+			cron.GenerateSchedule()
 		} else {
 			WorkerizeCmdHandler()
 		}
@@ -161,5 +168,28 @@ func WorkerizeCmdHandler() {
 		utils.HandleError(err)
 	}
 
+	// the orchestrator is registered with the lifecycle manager by main.go, so it is started with the servers and stopped before them
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		utils.HandleError(err)
+	}
+
+	if utils.FileExists(path.Join(workingDirectory, "main.go")) {
+		var regenerateMain bool
+		if err = survey.AskOne(&survey.Confirm{
+			Message: "Do you want to regenerate main.go so the workers are started on startup and stopped on shutdown? (main.go will be overwritten)",
+			Default: true,
+		}, &regenerateMain); err != nil {
+			utils.HandleError(err)
+		}
+
+		if regenerateMain {
+			err = initialize_utils.GenerateMainFile(initialize_utils.DetectMainData())
+			if err != nil {
+				utils.HandleError(err, "Error generating main.go file")
+			}
+		}
+	}
+
 	return
 }
@@ -0,0 +1,151 @@
+package cron
+
+import (
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/initialize_utils"
+	"github.com/davidh16/goblin/utils/workerize_utils"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+func GenerateSchedule() {
+	if !workerize_utils.IfWorkerizeIsInitialized() {
+		var confirmContinue bool
+		confirmContinuePrompt := &survey.Confirm{
+			Message: "There are missing workerize files, to implement a recurring job, workerize command needs to be initialized first, do you wish to continue?",
+			Default: false,
+		}
+		err := survey.AskOne(confirmContinuePrompt, &confirmContinue)
+		if err != nil {
+			utils.HandleError(err)
+		}
+		if !confirmContinue {
+			return
+		}
+
+		workerize_utils.WorkerizeCmdHandlerCopy()
+	}
+
+	generateScheduler := true
+	if workerize_utils.SchedulerImplemented() {
+		if err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("%s already exists, do you wish to overwrite? (declared schedules are kept in %s)", workerize_utils.SchedulerFileName, workerize_utils.SchedulesFileName),
+			Default: false,
+		}, &generateScheduler); err != nil {
+			utils.HandleError(err)
+		}
+	}
+
+	if generateScheduler || !utils.FileExists(path.Join(cli_config.CliConfig.WorkersFolderPath, workerize_utils.SchedulesFileName)) {
+		loggerImplemented := utils.FileExists(path.Join(cli_config.CliConfig.LoggerFolderPath, "logger.go"))
+		if err := workerize_utils.GenerateScheduler(loggerImplemented); err != nil {
+			utils.HandleError(err, "Error generating scheduler")
+		}
+	}
+
+	jobTypes, err := workerize_utils.ListJobTypes()
+	if err != nil {
+		utils.HandleError(err, "Unable to list job types")
+	}
+
+	if len(jobTypes) == 0 {
+		fmt.Println("ℹ️ There are no custom jobs to schedule yet, generate one with goblin workerize --job and run goblin workerize --cron again.")
+		return
+	}
+
+	existingSchedules, err := workerize_utils.ListSchedules()
+	if err != nil {
+		utils.HandleError(err, "Unable to list schedules")
+	}
+
+	schedule := &workerize_utils.ScheduleData{}
+
+	for {
+		if err = survey.AskOne(&survey.Input{
+			Message: "Please type the schedule name (snake_case):",
+			Default: "nightly_cleanup",
+		}, &schedule.Name); err != nil {
+			utils.HandleError(err)
+		}
+
+		if !utils.IsSnakeCase(schedule.Name) {
+			fmt.Printf("🛑 %s is not in snake case\n", schedule.Name)
+			continue
+		}
+
+		nameTaken := false
+		for _, existingSchedule := range existingSchedules {
+			if existingSchedule.Name == schedule.Name {
+				nameTaken = true
+				break
+			}
+		}
+		if nameTaken {
+			fmt.Printf("🛑 %s schedule already exists\n", schedule.Name)
+			continue
+		}
+		break
+	}
+
+	for {
+		if err = survey.AskOne(&survey.Input{
+			Message: "Please type the cron expression (minute hour day-of-month month day-of-week, or @hourly, @daily, @every 10m...):",
+			Default: "0 3 * * *",
+		}, &schedule.Spec); err != nil {
+			utils.HandleError(err)
+		}
+
+		nextRun, err := workerize_utils.ValidateCronSpec(schedule.Spec)
+		if err != nil {
+			fmt.Printf("🛑 %s is not a valid cron expression: %s\n", schedule.Spec, err.Error())
+			continue
+		}
+
+		fmt.Printf("ℹ️ The job will first be enqueued at %s\n", nextRun.Format(time.RFC1123))
+		break
+	}
+
+	jobTypeOptions := utils.Keys(jobTypes)
+	sort.Strings(jobTypeOptions)
+	if err = survey.AskOne(&survey.Select{
+		Message: "Select the job type to enqueue:",
+		Options: jobTypeOptions,
+	}, &schedule.JobTypeName); err != nil {
+		utils.HandleError(err)
+	}
+	schedule.JobMetadataName = jobTypes[schedule.JobTypeName]
+
+	if err = workerize_utils.AddSchedule(schedule); err != nil {
+		utils.HandleError(err, "Error adding schedule")
+	}
+
+	if generateScheduler {
+		// the scheduler is started with the orchestrator by main.go
+		workingDirectory, err := os.Getwd()
+		if err != nil {
+			utils.HandleError(err)
+		}
+
+		if utils.FileExists(path.Join(workingDirectory, "main.go")) {
+			var regenerateMain bool
+			if err = survey.AskOne(&survey.Confirm{
+				Message: "Do you want to regenerate main.go so the scheduler is started with the workers? (main.go will be overwritten)",
+				Default: true,
+			}, &regenerateMain); err != nil {
+				utils.HandleError(err)
+			}
+
+			if regenerateMain {
+				err = initialize_utils.GenerateMainFile(initialize_utils.DetectMainData())
+				if err != nil {
+					utils.HandleError(err, "Error generating main.go file")
+				}
+			}
+		}
+	}
+}
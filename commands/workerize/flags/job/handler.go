@@ -9,6 +9,8 @@ import (
 	"github.com/davidh16/goblin/utils/workerize_utils"
 	"github.com/spf13/cobra"
 	"path"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -45,6 +47,9 @@ func GenerateCustomJob() {
 
 	}
 
+	// the worker logs through the logger only once it has been generated
+	customJobData.LoggerImplemented = utils.FileExists(path.Join(cli_config.CliConfig.LoggerFolderPath, "logger.go"))
+
 	for {
 		if err := survey.AskOne(&survey.Input{
 			Message: "Please type the job file name (snake_case), keep in mind that it will get a suffix _job.go automatically:",
@@ -118,6 +123,8 @@ func GenerateCustomJob() {
 
 	}
 
+	chooseQueue(customJobData)
+
 	if customJobData.CreateWorkerPool {
 
 		customJobData.WorkerPoolNameSnakeCase = customJobData.JobNameSnakeCase + "_worker_pool"
@@ -202,6 +209,40 @@ func GenerateCustomJob() {
 
 		customJobData.WorkerPoolNumberOfRetries = chosenWorkerPoolNumberOfRetriesInt
 
+		for {
+			var chosenMaxConcurrency string
+			if err = survey.AskOne(&survey.Input{
+				Message: "Please type in max number of jobs handled at once, jobs above it wait, holding back the queue, until one finishes :",
+				Default: strconv.Itoa(customJobData.WorkerPoolSize),
+			}, &chosenMaxConcurrency); err != nil {
+				utils.HandleError(err)
+			}
+
+			customJobData.MaxConcurrency, err = strconv.Atoi(chosenMaxConcurrency)
+			if err != nil || customJobData.MaxConcurrency < 1 || customJobData.MaxConcurrency > customJobData.WorkerPoolSize {
+				fmt.Printf("🛑 max concurrency must be a number between 1 and the worker pool size (%d)\n", customJobData.WorkerPoolSize)
+				continue
+			}
+			break
+		}
+
+		for {
+			var chosenTimeout string
+			if err = survey.AskOne(&survey.Input{
+				Message: "Please type in job timeout in seconds, the worker has to return once its ctx is done (0 for no timeout) :",
+				Default: "0",
+			}, &chosenTimeout); err != nil {
+				utils.HandleError(err)
+			}
+
+			customJobData.TimeoutSeconds, err = strconv.Atoi(chosenTimeout)
+			if err != nil || customJobData.TimeoutSeconds < 0 {
+				fmt.Println("🛑 timeout must be a number of seconds, 0 or more")
+				continue
+			}
+			break
+		}
+
 		// list services
 		existingServices, err := service_utils.ListExistingServices()
 		if err != nil {
@@ -237,6 +278,11 @@ func GenerateCustomJob() {
 		}
 	}
 
+	err = workerize_utils.AddCustomJobToQueue(customJobData)
+	if err != nil {
+		utils.HandleError(err, "Error adding custom job to queue")
+	}
+
 	if customJobData.CreateWorkerPool {
 
 		if !customJobData.WorkerPoolExists || (customJobData.WorkerPoolExists && customJobData.WorkerPoolOverwrite) {
@@ -249,3 +295,72 @@ func GenerateCustomJob() {
 
 	return
 }
+
+// chooseQueue prompts for the queue the jobs are enqueued to, a new queue is declared with the priority it is polled with
+func chooseQueue(customJobData *workerize_utils.CustomJobData) {
+	existingQueues, err := workerize_utils.ListQueues()
+	if err != nil {
+		utils.HandleError(err)
+	}
+
+	const newQueueOption = "New queue"
+
+	queueOptions := utils.Keys(existingQueues)
+	sort.Strings(queueOptions)
+	options := make([]string, 0, len(queueOptions)+1)
+	for _, queue := range queueOptions {
+		options = append(options, fmt.Sprintf("%s (priority %d)", queue, existingQueues[queue]))
+	}
+	options = append(options, newQueueOption)
+
+	var selectedOption string
+	if err = survey.AskOne(&survey.Select{
+		Message: "Select a queue for the job, queues with higher priority are polled first more often:",
+		Options: options,
+	}, &selectedOption); err != nil {
+		utils.HandleError(err)
+	}
+
+	if selectedOption != newQueueOption {
+		customJobData.Queue = queueOptions[slices.Index(options, selectedOption)]
+		customJobData.QueuePriority = existingQueues[customJobData.Queue]
+		return
+	}
+
+	for {
+		if err = survey.AskOne(&survey.Input{
+			Message: "Please type the queue name (snake_case):",
+			Default: "urgent",
+		}, &customJobData.Queue); err != nil {
+			utils.HandleError(err)
+		}
+
+		if !utils.IsSnakeCase(customJobData.Queue) {
+			fmt.Printf("🛑 %s is not in snake case\n", customJobData.Queue)
+			continue
+		}
+
+		if _, exists := existingQueues[customJobData.Queue]; exists {
+			fmt.Printf("🛑 %s queue already exists\n", customJobData.Queue)
+			continue
+		}
+		break
+	}
+
+	for {
+		var chosenPriority string
+		if err = survey.AskOne(&survey.Input{
+			Message: fmt.Sprintf("Please type in %s queue priority, the default queue has priority %d :", customJobData.Queue, existingQueues[workerize_utils.DefaultQueue]),
+			Default: "3",
+		}, &chosenPriority); err != nil {
+			utils.HandleError(err)
+		}
+
+		customJobData.QueuePriority, err = strconv.Atoi(chosenPriority)
+		if err != nil || customJobData.QueuePriority < 1 {
+			fmt.Println("🛑 priority must be a number, 1 or more")
+			continue
+		}
+		break
+	}
+}
@@ -0,0 +1,47 @@
+package schedules
+
+import (
+	"errors"
+	"fmt"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/workerize_utils"
+	"github.com/spf13/cobra"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+var SchedulesCmd = &cobra.Command{
+	Use:   "schedules",
+	Short: "List the recurring jobs declared for the scheduler with their next run",
+	Run: func(cmd *cobra.Command, args []string) {
+		schedulesCmdHandler()
+	},
+}
+
+func schedulesCmdHandler() {
+	if !workerize_utils.SchedulerImplemented() {
+		utils.HandleError(errors.New("scheduler is not implemented, run goblin workerize --cron first"))
+	}
+
+	schedules, err := workerize_utils.ListSchedules()
+	if err != nil {
+		utils.HandleError(err, "Unable to list schedules")
+	}
+
+	if len(schedules) == 0 {
+		fmt.Println("ℹ️ No schedules are declared, add one with goblin workerize --cron.")
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tSPEC\tJOB TYPE\tNEXT RUN")
+	for _, schedule := range schedules {
+		nextRun := "invalid cron expression"
+		if next, err := workerize_utils.ValidateCronSpec(schedule.Spec); err == nil {
+			nextRun = next.Format(time.RFC1123)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", schedule.Name, schedule.Spec, schedule.JobTypeName, nextRun)
+	}
+	writer.Flush()
+}
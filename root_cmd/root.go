@@ -1,18 +1,37 @@
 package root_cmd
 
 import (
+	"github.com/davidh16/goblin/commands/auth"
+	"github.com/davidh16/goblin/commands/auth/rotate_keys"
 	"github.com/davidh16/goblin/commands/config"
 	"github.com/davidh16/goblin/commands/controller"
 	"github.com/davidh16/goblin/commands/database"
+	"github.com/davidh16/goblin/commands/deploy"
+	"github.com/davidh16/goblin/commands/deploy/k8s"
+	"github.com/davidh16/goblin/commands/docker"
+	"github.com/davidh16/goblin/commands/env"
+	"github.com/davidh16/goblin/commands/env/check"
+	"github.com/davidh16/goblin/commands/env/decrypt"
+	"github.com/davidh16/goblin/commands/env/edit"
+	"github.com/davidh16/goblin/commands/env/encrypt"
+	"github.com/davidh16/goblin/commands/env/example"
+	"github.com/davidh16/goblin/commands/grpc"
 	"github.com/davidh16/goblin/commands/initialize"
+	"github.com/davidh16/goblin/commands/jobs"
+	"github.com/davidh16/goblin/commands/jobs/list"
+	"github.com/davidh16/goblin/commands/jobs/purge"
+	"github.com/davidh16/goblin/commands/jobs/retry"
+	"github.com/davidh16/goblin/commands/jobs/show"
 	"github.com/davidh16/goblin/commands/logger"
 	"github.com/davidh16/goblin/commands/middleware"
 	"github.com/davidh16/goblin/commands/migration"
 	"github.com/davidh16/goblin/commands/model"
+	"github.com/davidh16/goblin/commands/observability"
 	"github.com/davidh16/goblin/commands/repo"
 	"github.com/davidh16/goblin/commands/router"
 	"github.com/davidh16/goblin/commands/service"
 	"github.com/davidh16/goblin/commands/workerize"
+	"github.com/davidh16/goblin/commands/workerize/schedules"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -69,6 +88,8 @@ func init() {
 
 	rootCmd.AddCommand(workerize.WorkerizeCmd)
 	workerize.WorkerizeCmd.Flags().BoolVarP(&workerize.CustomJobFlag, "job", "j", false, "Generate custom job")
+	workerize.WorkerizeCmd.Flags().BoolVarP(&workerize.CronFlag, "cron", "c", false, "Generate scheduler and add a recurring job")
+	workerize.WorkerizeCmd.AddCommand(schedules.SchedulesCmd)
 
 	rootCmd.AddCommand(logger.LoggerCmd)
 
@@ -79,4 +100,42 @@ func init() {
 	rootCmd.AddCommand(middleware.MiddlewareCmd)
 
 	rootCmd.AddCommand(initialize.InitializeCmd)
+
+	rootCmd.AddCommand(grpc.GrpcCmd)
+
+	rootCmd.AddCommand(auth.AuthCmd)
+	auth.AuthCmd.AddCommand(rotate_keys.RotateKeysCmd)
+
+	rootCmd.AddCommand(observability.ObservabilityCmd)
+
+	rootCmd.AddCommand(env.EnvCmd)
+	env.EnvCmd.PersistentFlags().StringVarP(&env.ProfileFlag, "profile", "p", "", "Use .env.<profile> instead of .env (dev, test or prod)")
+	env.EnvCmd.AddCommand(check.CheckCmd)
+	env.EnvCmd.AddCommand(example.ExampleCmd)
+	env.EnvCmd.AddCommand(encrypt.EncryptCmd)
+	env.EnvCmd.AddCommand(decrypt.DecryptCmd)
+	env.EnvCmd.AddCommand(edit.EditCmd)
+
+	rootCmd.AddCommand(docker.DockerCmd)
+
+	rootCmd.AddCommand(deploy.DeployCmd)
+	deploy.DeployCmd.AddCommand(k8s.K8sCmd)
+
+	rootCmd.AddCommand(jobs.JobsCmd)
+	jobs.JobsCmd.AddCommand(list.ListCmd)
+	list.ListCmd.Flags().StringVarP(&list.FilterFlags.JobType, "type", "t", "", "Job type name, i.e email for JobTypeEmail")
+	list.ListCmd.Flags().DurationVar(&list.FilterFlags.Since, "since", 0, "Jobs which failed within the duration, i.e 24h")
+	list.ListCmd.Flags().DurationVar(&list.FilterFlags.Until, "until", 0, "Jobs which failed before the duration, i.e 1h")
+	list.ListCmd.Flags().IntVarP(&list.LimitFlag, "limit", "l", 50, "Max number of jobs listed")
+	jobs.JobsCmd.AddCommand(show.ShowCmd)
+	jobs.JobsCmd.AddCommand(retry.RetryCmd)
+	retry.RetryCmd.Flags().BoolVarP(&retry.AllFlag, "all", "a", false, "Retry every failed job matching the filter flags")
+	retry.RetryCmd.Flags().StringVarP(&retry.FilterFlags.JobType, "type", "t", "", "Job type name, i.e email for JobTypeEmail")
+	retry.RetryCmd.Flags().DurationVar(&retry.FilterFlags.Since, "since", 0, "Jobs which failed within the duration, i.e 24h")
+	retry.RetryCmd.Flags().DurationVar(&retry.FilterFlags.Until, "until", 0, "Jobs which failed before the duration, i.e 1h")
+	jobs.JobsCmd.AddCommand(purge.PurgeCmd)
+	purge.PurgeCmd.Flags().BoolVarP(&purge.AllFlag, "all", "a", false, "Purge every failed job matching the filter flags")
+	purge.PurgeCmd.Flags().StringVarP(&purge.FilterFlags.JobType, "type", "t", "", "Job type name, i.e email for JobTypeEmail")
+	purge.PurgeCmd.Flags().DurationVar(&purge.FilterFlags.Since, "since", 0, "Jobs which failed within the duration, i.e 24h")
+	purge.PurgeCmd.Flags().DurationVar(&purge.FilterFlags.Until, "until", 0, "Jobs which failed before the duration, i.e 720h")
 }
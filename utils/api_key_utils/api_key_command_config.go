@@ -0,0 +1,16 @@
+package api_key_utils
+
+const (
+	ApiKeyModelTemplatePath = "api_key_model.tmpl"
+	ApiKeyModelFileName     = "api_key.go"
+
+	ApiKeysMigrationUpTemplatePath   = "api_keys_migration_up.tmpl"
+	ApiKeysMigrationDownTemplatePath = "api_keys_migration_down.tmpl"
+	ApiKeysMigrationName             = "api_keys"
+
+	ApiKeyRepoTemplatePath = "api_key_repo.tmpl"
+	ApiKeyRepoFileName     = "api_key_repo.go"
+
+	ApiKeyServiceTemplatePath = "api_key_service.tmpl"
+	ApiKeyServiceFileName     = "api_key_service.go"
+)
@@ -0,0 +1,132 @@
+package api_key_utils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/templates"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/apperrors_utils"
+	"github.com/davidh16/goblin/utils/migration_utils"
+	"github.com/davidh16/goblin/utils/repo_utils"
+	"github.com/davidh16/goblin/utils/service_utils"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// GenerateApiKeys generates the api_keys model, its migrations and the repo and service issuing and revoking keys.
+// Already generated files are left untouched, so running it twice does not inject the repo and service into the central structs twice.
+func GenerateApiKeys() error {
+	if !utils.FileExists(path.Join(cli_config.CliConfig.RepositoriesFolderPath, "central_repo.go")) {
+		return errors.New("central repository does not exist, run goblin repo --central-repo first")
+	}
+
+	if !utils.FileExists(path.Join(cli_config.CliConfig.ServicesFolderPath, "central_service.go")) {
+		return errors.New("central service does not exist, run goblin service --central-service first")
+	}
+
+	err := apperrors_utils.EnsureAppErrors()
+	if err != nil {
+		return err
+	}
+
+	templateData := struct {
+		ModelsPackage          string
+		ModelsPackageImport    string
+		RepoPackage            string
+		RepoPackageImport      string
+		ServicePackage         string
+		AppErrorsPackage       string
+		AppErrorsPackageImport string
+	}{
+		ModelsPackage:          strings.Split(cli_config.CliConfig.ModelsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ModelsFolderPath, "/"))-1],
+		ModelsPackageImport:    path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ModelsFolderPath),
+		RepoPackage:            strings.Split(cli_config.CliConfig.RepositoriesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.RepositoriesFolderPath, "/"))-1],
+		RepoPackageImport:      path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.RepositoriesFolderPath),
+		ServicePackage:         strings.Split(cli_config.CliConfig.ServicesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ServicesFolderPath, "/"))-1],
+		AppErrorsPackage:       apperrors_utils.AppErrorsPackage(),
+		AppErrorsPackageImport: apperrors_utils.AppErrorsPackageImport(),
+	}
+
+	err = os.MkdirAll(cli_config.CliConfig.ModelsFolderPath, 0755)
+	if err != nil {
+		return err
+	}
+
+	apiKeyModelPath := path.Join(cli_config.CliConfig.ModelsFolderPath, ApiKeyModelFileName)
+	if !utils.FileExists(apiKeyModelPath) {
+		err = renderTemplate(ApiKeyModelTemplatePath, apiKeyModelPath, templateData)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = migration_utils.GenerateTemplatedMigrationFiles(ApiKeysMigrationName, ApiKeysMigrationUpTemplatePath, ApiKeysMigrationDownTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	apiKeyRepoPath := path.Join(cli_config.CliConfig.RepositoriesFolderPath, ApiKeyRepoFileName)
+	apiKeyRepoData := repo_utils.RepoData{
+		RepoNameSnakeCase: "api_key",
+		RepoEntity:        "ApiKey",
+		RepoFullName:      "ApiKeyRepo",
+		RepoFileName:      ApiKeyRepoFileName,
+		RepoFilePath:      apiKeyRepoPath,
+	}
+	if !utils.FileExists(apiKeyRepoPath) {
+		err = renderTemplate(ApiKeyRepoTemplatePath, apiKeyRepoPath, templateData)
+		if err != nil {
+			return err
+		}
+
+		err = repo_utils.AddNewRepoToCentralRepo(&apiKeyRepoData)
+		if err != nil {
+			return err
+		}
+	}
+
+	apiKeyServicePath := path.Join(cli_config.CliConfig.ServicesFolderPath, ApiKeyServiceFileName)
+	if !utils.FileExists(apiKeyServicePath) {
+		err = renderTemplate(ApiKeyServiceTemplatePath, apiKeyServicePath, templateData)
+		if err != nil {
+			return err
+		}
+
+		err = service_utils.AddNewServiceToCentralService(&service_utils.ServiceData{
+			ServiceNameSnakeCase: "api_key",
+			ServiceEntity:        "ApiKey",
+			ServiceFullName:      "ApiKeyService",
+			ServiceFileName:      ApiKeyServiceFileName,
+			ServiceFilePath:      apiKeyServicePath,
+			RepoData:             []repo_utils.RepoData{apiKeyRepoData},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderTemplate(templatePath, filePath string, templateData any) error {
+	tmpl, err := template.ParseFS(templates.Files, templatePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = tmpl.Execute(f, templateData)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", filePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+package app_utils
+
+const (
+	AppTemplatePath = "app.tmpl"
+	AppFileName     = "app.go"
+)
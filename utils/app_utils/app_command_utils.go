@@ -0,0 +1,55 @@
+package app_utils
+
+import (
+	"fmt"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/templates"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// AppPackage returns the package name of the generated lifecycle manager
+func AppPackage() string {
+	return strings.Split(cli_config.CliConfig.AppFolderPath, "/")[len(strings.Split(cli_config.CliConfig.AppFolderPath, "/"))-1]
+}
+
+// AppPackageImport returns the import path of the generated lifecycle manager
+func AppPackageImport() string {
+	return path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.AppFolderPath)
+}
+
+// GenerateApp generates the lifecycle manager main.go registers the databases, workers and servers with
+func GenerateApp() error {
+	err := os.MkdirAll(cli_config.CliConfig.AppFolderPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.ParseFS(templates.Files, AppTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	filePath := path.Join(cli_config.CliConfig.AppFolderPath, AppFileName)
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	templateData := struct {
+		AppPackage string
+	}{
+		AppPackage: AppPackage(),
+	}
+
+	err = tmpl.Execute(f, templateData)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", filePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+package apperrors_utils
+
+const (
+	AppErrorsTemplatePath = "apperrors.tmpl"
+	AppErrorsFileName     = "apperrors.go"
+
+	ProblemDetailsTemplatePath = "apperrors_problem.tmpl"
+	ProblemDetailsFileName     = "problem.go"
+)
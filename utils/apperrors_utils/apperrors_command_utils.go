@@ -0,0 +1,68 @@
+package apperrors_utils
+
+import (
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/templates"
+	"github.com/davidh16/goblin/utils"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// AppErrorsPackage returns the package name of the generated apperrors package
+func AppErrorsPackage() string {
+	return strings.Split(cli_config.CliConfig.AppErrorsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.AppErrorsFolderPath, "/"))-1]
+}
+
+// AppErrorsPackageImport returns the import path of the generated apperrors package
+func AppErrorsPackageImport() string {
+	return path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.AppErrorsFolderPath)
+}
+
+// EnsureAppErrors generates the apperrors package unless it already exists.
+// Repositories, the router and the logging middleware depend on it, so every generator using it calls this first.
+func EnsureAppErrors() error {
+	if utils.FileExists(path.Join(cli_config.CliConfig.AppErrorsFolderPath, AppErrorsFileName)) {
+		return nil
+	}
+
+	return GenerateAppErrors()
+}
+
+// GenerateAppErrors generates the typed domain errors and the problem+json rendering of them
+func GenerateAppErrors() error {
+	err := os.MkdirAll(cli_config.CliConfig.AppErrorsFolderPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	templateData := struct {
+		AppErrorsPackage string
+	}{
+		AppErrorsPackage: AppErrorsPackage(),
+	}
+
+	for templatePath, fileName := range map[string]string{
+		AppErrorsTemplatePath:      AppErrorsFileName,
+		ProblemDetailsTemplatePath: ProblemDetailsFileName,
+	} {
+		tmpl, err := template.ParseFS(templates.Files, templatePath)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(path.Join(cli_config.CliConfig.AppErrorsFolderPath, fileName))
+		if err != nil {
+			return err
+		}
+
+		err = tmpl.Execute(f, templateData)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
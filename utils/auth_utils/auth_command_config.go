@@ -0,0 +1,37 @@
+package auth_utils
+
+const (
+	RefreshTokenModelTemplatePath = "auth_refresh_token_model.tmpl"
+	RefreshTokenModelFileName     = "refresh_token.go"
+
+	RefreshTokensMigrationUpTemplatePath   = "auth_refresh_tokens_migration_up.tmpl"
+	RefreshTokensMigrationDownTemplatePath = "auth_refresh_tokens_migration_down.tmpl"
+	RefreshTokensMigrationName             = "refresh_tokens"
+
+	AuthRepoTemplatePath = "auth_repo.tmpl"
+	AuthRepoFileName     = "auth_repo.go"
+
+	AuthServiceTemplatePath = "auth_service.tmpl"
+	AuthServiceFileName     = "auth_service.go"
+
+	AuthControllerTemplatePath = "auth_controller.tmpl"
+	AuthControllerFileName     = "auth_controller.go"
+
+	RbacModelsTemplatePath = "rbac_models.tmpl"
+	RbacModelsFileName     = "role.go"
+
+	RbacMigrationUpTemplatePath   = "rbac_migration_up.tmpl"
+	RbacMigrationDownTemplatePath = "rbac_migration_down.tmpl"
+	RbacMigrationName             = "roles_and_permissions"
+
+	RbacPolicyTemplatePath = "rbac_policy.tmpl"
+	RbacPolicyFileName     = "policy.go"
+
+	SigningMethodHS512 = "HS512"
+	SigningMethodRS256 = "RS256"
+	SigningMethodEdDSA = "EdDSA"
+
+	rsaKeySize = 2048
+)
+
+var SigningMethodOptions = []string{SigningMethodHS512, SigningMethodRS256, SigningMethodEdDSA}
@@ -0,0 +1,419 @@
+package auth_utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/templates"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/apperrors_utils"
+	"github.com/davidh16/goblin/utils/controller_utils"
+	"github.com/davidh16/goblin/utils/middleware_utils"
+	"github.com/davidh16/goblin/utils/migration_utils"
+	"github.com/davidh16/goblin/utils/repo_utils"
+	"github.com/davidh16/goblin/utils/service_utils"
+	"github.com/samber/lo"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// AuthImplemented reports whether the auth controller has already been generated
+func AuthImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.ControllersFolderPath, AuthControllerFileName))
+}
+
+// AuthPackage returns the package name of the generated auth package
+func AuthPackage() string {
+	return strings.Split(cli_config.CliConfig.AuthFolderPath, "/")[len(strings.Split(cli_config.CliConfig.AuthFolderPath, "/"))-1]
+}
+
+// AuthPackageImport returns the import path of the generated auth package
+func AuthPackageImport() string {
+	return path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.AuthFolderPath)
+}
+
+// RbacImplemented reports whether roles and permissions have been generated
+func RbacImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.AuthFolderPath, RbacPolicyFileName))
+}
+
+type authTemplateData struct {
+	AuthPackage            string
+	AuthPackageImport      string
+	ModelsPackage          string
+	ModelsPackageImport    string
+	RepoPackage            string
+	RepoPackageImport      string
+	ServicePackage         string
+	ServicePackageImport   string
+	ControllerPackage      string
+	AppErrorsPackage       string
+	AppErrorsPackageImport string
+	RbacImplemented        bool
+}
+
+func newAuthTemplateData() authTemplateData {
+	return authTemplateData{
+		AuthPackage:            AuthPackage(),
+		AuthPackageImport:      AuthPackageImport(),
+		ModelsPackage:          strings.Split(cli_config.CliConfig.ModelsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ModelsFolderPath, "/"))-1],
+		ModelsPackageImport:    path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ModelsFolderPath),
+		RepoPackage:            strings.Split(cli_config.CliConfig.RepositoriesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.RepositoriesFolderPath, "/"))-1],
+		RepoPackageImport:      path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.RepositoriesFolderPath),
+		ServicePackage:         strings.Split(cli_config.CliConfig.ServicesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ServicesFolderPath, "/"))-1],
+		ServicePackageImport:   path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ServicesFolderPath),
+		ControllerPackage:      strings.Split(cli_config.CliConfig.ControllersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ControllersFolderPath, "/"))-1],
+		AppErrorsPackage:       apperrors_utils.AppErrorsPackage(),
+		AppErrorsPackageImport: apperrors_utils.AppErrorsPackageImport(),
+		RbacImplemented:        RbacImplemented(),
+	}
+}
+
+// GenerateAuth generates register, login, refresh and logout on top of the User model, access tokens are signed with the given signing method.
+// Already generated files are left untouched, so running the command twice does not inject the auth layers into the central structs twice.
+func GenerateAuth(signingMethod string) error {
+
+	err := apperrors_utils.EnsureAppErrors()
+	if err != nil {
+		return err
+	}
+
+	err = ensureJwt()
+	if err != nil {
+		return err
+	}
+
+	templateData := newAuthTemplateData()
+
+	refreshTokenModelPath := path.Join(cli_config.CliConfig.ModelsFolderPath, RefreshTokenModelFileName)
+	if !utils.FileExists(refreshTokenModelPath) {
+		err = renderTemplate(RefreshTokenModelTemplatePath, refreshTokenModelPath, templateData)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = migration_utils.GenerateTemplatedMigrationFiles(RefreshTokensMigrationName, RefreshTokensMigrationUpTemplatePath, RefreshTokensMigrationDownTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	authRepoPath := path.Join(cli_config.CliConfig.RepositoriesFolderPath, AuthRepoFileName)
+	authRepoData := repo_utils.RepoData{
+		RepoNameSnakeCase: "auth",
+		RepoEntity:        "Auth",
+		RepoFullName:      "AuthRepo",
+		RepoFileName:      AuthRepoFileName,
+		RepoFilePath:      authRepoPath,
+	}
+	if !utils.FileExists(authRepoPath) {
+		err = renderTemplate(AuthRepoTemplatePath, authRepoPath, templateData)
+		if err != nil {
+			return err
+		}
+
+		err = repo_utils.AddNewRepoToCentralRepo(&authRepoData)
+		if err != nil {
+			return err
+		}
+	}
+
+	authServicePath := path.Join(cli_config.CliConfig.ServicesFolderPath, AuthServiceFileName)
+	authServiceData := service_utils.ServiceData{
+		ServiceNameSnakeCase: "auth",
+		ServiceEntity:        "Auth",
+		ServiceFullName:      "AuthService",
+		ServiceFileName:      AuthServiceFileName,
+		ServiceFilePath:      authServicePath,
+		RepoData:             []repo_utils.RepoData{authRepoData},
+	}
+	if !utils.FileExists(authServicePath) {
+		err = renderTemplate(AuthServiceTemplatePath, authServicePath, templateData)
+		if err != nil {
+			return err
+		}
+
+		err = service_utils.AddNewServiceToCentralService(&authServiceData)
+		if err != nil {
+			return err
+		}
+	}
+
+	authControllerPath := path.Join(cli_config.CliConfig.ControllersFolderPath, AuthControllerFileName)
+	if !utils.FileExists(authControllerPath) {
+		err = renderTemplate(AuthControllerTemplatePath, authControllerPath, templateData)
+		if err != nil {
+			return err
+		}
+
+		err = controller_utils.AddNewControllerToCentralController(&controller_utils.ControllerData{
+			ControllerNameSnakeCase: "auth",
+			ControllerEntity:        "Auth",
+			ControllerFullName:      "AuthController",
+			ControllerFileName:      AuthControllerFileName,
+			ControllerFilePath:      authControllerPath,
+			ServiceData:             []service_utils.ServiceData{authServiceData},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return ConfigureSigningMethod(signingMethod)
+}
+
+// GenerateRbac generates roles and permissions on top of the generated auth layer.
+// The auth repo and service are regenerated so access tokens carry the roles of the user.
+func GenerateRbac() error {
+	if !AuthImplemented() {
+		return errors.New("auth does not exist, run goblin auth first")
+	}
+
+	err := ensureJwt()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(cli_config.CliConfig.ModelsFolderPath, 0755)
+	if err != nil {
+		return err
+	}
+
+	templateData := newAuthTemplateData()
+
+	rbacModelsPath := path.Join(cli_config.CliConfig.ModelsFolderPath, RbacModelsFileName)
+	if !utils.FileExists(rbacModelsPath) {
+		err = renderTemplate(RbacModelsTemplatePath, rbacModelsPath, templateData)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = migration_utils.GenerateTemplatedMigrationFiles(RbacMigrationName, RbacMigrationUpTemplatePath, RbacMigrationDownTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	err = renderTemplate(RbacPolicyTemplatePath, path.Join(cli_config.CliConfig.AuthFolderPath, RbacPolicyFileName), templateData)
+	if err != nil {
+		return err
+	}
+
+	templateData.RbacImplemented = true
+
+	err = renderTemplate(AuthRepoTemplatePath, path.Join(cli_config.CliConfig.RepositoriesFolderPath, AuthRepoFileName), templateData)
+	if err != nil {
+		return err
+	}
+
+	return renderTemplate(AuthServiceTemplatePath, path.Join(cli_config.CliConfig.ServicesFolderPath, AuthServiceFileName), templateData)
+}
+
+// ensureJwt makes sure JwtMiddleware and an auth package providing refresh tokens, signing keys and role claims exist.
+// An auth package generated by an older version of goblin only knows about access tokens signed with JWT_SECRET, so it is regenerated.
+func ensureJwt() error {
+	if !utils.FileExists(path.Join(cli_config.CliConfig.MiddlewaresFolderPath, middleware_utils.MiddlewareOptionTemplateFileNameMap["JwtMiddleware"])) {
+		err := middleware_utils.GenerateMiddlewares([]string{"JwtMiddleware"})
+		if err != nil {
+			return err
+		}
+	}
+
+	jwtFile, err := os.ReadFile(path.Join(cli_config.CliConfig.AuthFolderPath, middleware_utils.AuthJwtFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if strings.Contains(string(jwtFile), `json:"roles`) && utils.FileExists(path.Join(cli_config.CliConfig.AuthFolderPath, middleware_utils.AuthKeysFileName)) {
+		return nil
+	}
+
+	return middleware_utils.GenerateAuthJwtFile()
+}
+
+// ConfigureSigningMethod writes the token lifetimes and the signing method to the .env file.
+// HS512 signs with a random JWT_SECRET, RS256 and EdDSA sign with a keypair generated into the keys folder unless one already exists.
+func ConfigureSigningMethod(signingMethod string) error {
+	if !lo.Contains(SigningMethodOptions, signingMethod) {
+		return fmt.Errorf("unsupported signing method %s", signingMethod)
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	envFile, err := os.OpenFile(path.Join(workingDirectory, ".env"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer envFile.Close()
+
+	existingEnv, err := utils.ReadEnvFile(envFile)
+	if err != nil {
+		return err
+	}
+
+	newEnv := map[string]string{
+		"JWT_SIGNING_METHOD": signingMethod,
+	}
+	for key, value := range map[string]string{
+		"JWT_ACCESS_TOKEN_TTL":  "15m",
+		"JWT_REFRESH_TOKEN_TTL": "720h",
+	} {
+		if existingEnv[key] == "" {
+			newEnv[key] = value
+		}
+	}
+
+	if signingMethod != SigningMethodHS512 {
+		newEnv["JWT_KEYS_DIR"] = cli_config.CliConfig.KeysFolderPath
+	}
+
+	err = utils.WriteToEnvFile(envFile, newEnv)
+	if err != nil {
+		return err
+	}
+
+	if signingMethod == SigningMethodHS512 {
+		return middleware_utils.EnsureJwtSecret()
+	}
+
+	existingKeys, err := ListSigningKeys()
+	if err != nil {
+		return err
+	}
+
+	if len(existingKeys) > 0 {
+		return nil
+	}
+
+	_, err = GenerateSigningKey(signingMethod)
+	return err
+}
+
+// ListSigningKeys returns the key ids found in the keys folder, oldest first
+func ListSigningKeys() ([]string, error) {
+	keyFiles, err := filepath.Glob(path.Join(cli_config.CliConfig.KeysFolderPath, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keyFiles)
+
+	var kids []string
+	for _, keyFile := range keyFiles {
+		kids = append(kids, strings.TrimSuffix(filepath.Base(keyFile), ".pem"))
+	}
+
+	return kids, nil
+}
+
+// GenerateSigningKey generates a new RS256 or EdDSA private key into the keys folder and returns its key id.
+// Key ids are creation timestamps, the generated app signs with the newest key once it is JWT_KEY_ACTIVATION_DELAY old
+// and keeps verifying tokens with the older ones.
+func GenerateSigningKey(signingMethod string) (string, error) {
+	var privateKey any
+	var err error
+
+	switch signingMethod {
+	case SigningMethodRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case SigningMethodEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return "", fmt.Errorf("%s does not use signing keys", signingMethod)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(cli_config.CliConfig.KeysFolderPath, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	// private keys must never end up in version control
+	err = utils.AddToGitignore(strings.TrimSuffix(cli_config.CliConfig.KeysFolderPath, "/") + "/")
+	if err != nil {
+		return "", err
+	}
+
+	kid := time.Now().UTC().Format("20060102150405")
+
+	// O_EXCL keeps a second rotation within the same second from overwriting the key just generated
+	f, err := os.OpenFile(path.Join(cli_config.CliConfig.KeysFolderPath, kid+".pem"), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	err = pem.Encode(f, &pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err != nil {
+		return "", err
+	}
+
+	return kid, nil
+}
+
+// RotateKeys adds a new signing key for the signing method configured in the .env file.
+// Older keys are kept, so tokens signed with them remain valid until they expire.
+func RotateKeys() (string, error) {
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	envFile, err := os.Open(path.Join(workingDirectory, ".env"))
+	if err != nil {
+		return "", err
+	}
+	defer envFile.Close()
+
+	existingEnv, err := utils.ReadEnvFile(envFile)
+	if err != nil {
+		return "", err
+	}
+
+	signingMethod := existingEnv["JWT_SIGNING_METHOD"]
+	if signingMethod == "" || signingMethod == SigningMethodHS512 {
+		return "", errors.New("tokens are signed with HS512 and JWT_SECRET, run goblin auth and choose RS256 or EdDSA to sign with rotatable keys")
+	}
+
+	return GenerateSigningKey(signingMethod)
+}
+
+func renderTemplate(templatePath, filePath string, templateData any) error {
+	tmpl, err := template.ParseFS(templates.Files, templatePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = tmpl.Execute(f, templateData)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", filePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package config_utils
+
+const (
+	ConfigTemplatePath        = "config.tmpl"
+	ConfigFileName            = "config.go"
+	ConfigSectionTemplatePath = "config_section.tmpl"
+	ConfigSecretsTemplatePath = "config_secrets.tmpl"
+	ConfigSecretsFileName     = "secrets.go"
+
+	// ProfileVariable selects the profile whose .env.<profile> file is loaded on top of .env
+	ProfileVariable = "APP_ENV"
+
+	// EncryptionKeyVariable holds the key decrypting the .env.enc files, EncryptionKeyFileVariable the path of the file holding it
+	EncryptionKeyVariable     = "ENV_ENCRYPTION_KEY"
+	EncryptionKeyFileVariable = "ENV_ENCRYPTION_KEY_FILE"
+	EncryptionKeyFileName     = ".env.key"
+	EncryptedFileSuffix       = ".enc"
+	EncryptedValuePrefix      = "enc:"
+)
+
+// LoaderVariables are read by Load itself to find the env files, they are not set in them
+var LoaderVariables = []string{ProfileVariable, EncryptionKeyVariable, EncryptionKeyFileVariable}
+
+const (
+	ServerSection    = "Server"
+	LoggerSection    = "Logger"
+	PostgresSection  = "Postgres"
+	MariaDBSection   = "MariaDB"
+	RedisSection     = "Redis"
+	JwtSection       = "Jwt"
+	CorsSection      = "Cors"
+	RateLimitSection = "RateLimit"
+	GrpcSection      = "Grpc"
+	MetricsSection   = "Metrics"
+	TracingSection   = "Tracing"
+)
+
+// ConfigField is a field of a section, it is loaded from the Env variable
+type ConfigField struct {
+	Name     string
+	Type     string
+	Env      string
+	Default  string
+	Required bool
+	Secret   bool // secret values are redacted when the config is printed
+	Comment  string
+}
+
+// ConfigSection is rendered into <FileName> as the <Name>Config struct and added to Config as its <Name> field
+type ConfigSection struct {
+	Name     string
+	FileName string
+	Comment  string
+	Fields   []ConfigField
+}
+
+// ConfigSections lists every section generators can add, Config holds the generated ones in this order
+var ConfigSections = []ConfigSection{
+	{
+		Name:     ServerSection,
+		FileName: "server.go",
+		Comment:  "is the address the router listens on",
+		Fields: []ConfigField{
+			{Name: "Address", Type: "string", Env: "SERVER_BIND_ADDRESS", Default: "0.0.0.0"},
+			{Name: "Port", Type: "string", Env: "SERVER_BIND_PORT", Default: "8080"},
+		},
+	},
+	{
+		Name:     LoggerSection,
+		FileName: "logger.go",
+		Comment:  "configures the level of logs and where they are forwarded to",
+		Fields: []ConfigField{
+			{Name: "Level", Type: "string", Env: "LOG_LEVEL", Default: "info", Comment: "debug, info, warn, error, fatal or panic"},
+			{Name: "GraylogAddress", Type: "string", Env: "GRAYLOG_ADDRESS", Comment: "logs are forwarded to Graylog when it is set"},
+		},
+	},
+	{
+		Name:     PostgresSection,
+		FileName: "postgres.go",
+		Comment:  "is the PostgreSQL database ConnectToPostgres connects to",
+		Fields: []ConfigField{
+			{Name: "User", Type: "string", Env: "POSTGRES_USER", Required: true},
+			{Name: "Password", Type: "string", Env: "POSTGRES_PASSWORD", Secret: true},
+			{Name: "Db", Type: "string", Env: "POSTGRES_DB", Required: true},
+			{Name: "Host", Type: "string", Env: "POSTGRES_HOST", Default: "localhost"},
+			{Name: "Port", Type: "string", Env: "POSTGRES_PORT", Default: "5432"},
+		},
+	},
+	{
+		Name:     MariaDBSection,
+		FileName: "mariadb.go",
+		Comment:  "is the MariaDB database ConnectToMariaDB connects to",
+		Fields: []ConfigField{
+			{Name: "User", Type: "string", Env: "MARIADB_USER", Required: true},
+			{Name: "Password", Type: "string", Env: "MARIADB_PASSWORD", Secret: true},
+			{Name: "Db", Type: "string", Env: "MARIADB_DB", Required: true},
+			{Name: "Host", Type: "string", Env: "MARIADB_HOST", Default: "localhost"},
+			{Name: "Port", Type: "string", Env: "MARIADB_PORT", Default: "3306"},
+		},
+	},
+	{
+		Name:     RedisSection,
+		FileName: "redis.go",
+		Comment:  "is the Redis server ConnectToRedis and the jobs queue connect to",
+		Fields: []ConfigField{
+			{Name: "Host", Type: "string", Env: "REDIS_HOST", Default: "localhost"},
+			{Name: "Port", Type: "string", Env: "REDIS_PORT", Default: "6379"},
+			{Name: "Password", Type: "string", Env: "REDIS_PASSWORD", Secret: true},
+			{Name: "Db", Type: "int", Env: "REDIS_DB", Default: "0", Comment: "index of the logical database"},
+		},
+	},
+	{
+		Name:     JwtSection,
+		FileName: "jwt.go",
+		Comment:  "configures how access tokens are signed and how long tokens are valid for",
+		Fields: []ConfigField{
+			{Name: "SigningMethod", Type: "string", Env: "JWT_SIGNING_METHOD", Default: "HS512", Comment: "HS512, RS256 or EdDSA"},
+			{Name: "Secret", Type: "string", Env: "JWT_SECRET", Secret: true, Comment: "signs tokens when the signing method is HS512"},
+			{Name: "KeysDir", Type: "string", Env: "JWT_KEYS_DIR", Comment: "holds the keypairs when the signing method is RS256 or EdDSA"},
+			{Name: "KeyActivationDelay", Type: "time.Duration", Env: "JWT_KEY_ACTIVATION_DELAY", Default: "1h", Comment: "how long a rotated key is only published for verification before it signs tokens"},
+			{Name: "KeysReloadInterval", Type: "time.Duration", Env: "JWT_KEYS_RELOAD_INTERVAL", Default: "1m", Comment: "how often the keys are reloaded from JWT_KEYS_DIR, they are reloaded on SIGHUP as well"},
+			{Name: "AccessTokenTtl", Type: "time.Duration", Env: "JWT_ACCESS_TOKEN_TTL", Default: "15m"},
+			{Name: "RefreshTokenTtl", Type: "time.Duration", Env: "JWT_REFRESH_TOKEN_TTL", Default: "720h"},
+		},
+	},
+	{
+		Name:     CorsSection,
+		FileName: "cors.go",
+		Comment:  "lists the origins AllowOriginMiddleware allows",
+		Fields: []ConfigField{
+			{Name: "AllowOrigins", Type: "[]string", Env: "ALLOW_ORIGINS", Comment: "comma separated origins, i.e https://example.com"},
+			{Name: "AllowOriginsWildcards", Type: "[]string", Env: "ALLOW_ORIGINS_WILDCARDS", Comment: "comma separated domains whose subdomains are allowed as well, i.e example.com"},
+		},
+	},
+	{
+		Name:     RateLimitSection,
+		FileName: "rate_limit.go",
+		Comment:  "is the default rate limit, the limits of route groups and identities are set in the policies file",
+		Fields: []ConfigField{
+			{Name: "PoliciesFile", Type: "string", Env: "RATE_LIMIT_POLICIES_FILE", Default: "rate_limits.yaml"},
+			{Name: "Limit", Type: "int", Env: "RATE_LIMIT_LIMIT", Default: "100", Comment: "requests allowed per window"},
+			{Name: "Window", Type: "time.Duration", Env: "RATE_LIMIT_WINDOW", Default: "1m"},
+			{Name: "FailOpen", Type: "bool", Env: "RATE_LIMIT_FAIL_OPEN", Default: "true", Comment: "allows requests while Redis is unreachable"},
+		},
+	},
+	{
+		Name:     GrpcSection,
+		FileName: "grpc.go",
+		Comment:  "is the address the gRPC server listens on",
+		Fields: []ConfigField{
+			{Name: "Address", Type: "string", Env: "GRPC_BIND_ADDRESS", Default: "0.0.0.0"},
+			{Name: "Port", Type: "string", Env: "GRPC_BIND_PORT", Default: "50051"},
+		},
+	},
+	{
+		Name:     MetricsSection,
+		FileName: "metrics.go",
+		Comment:  "is the admin address /metrics is served on",
+		Fields: []ConfigField{
+			{Name: "Address", Type: "string", Env: "METRICS_BIND_ADDRESS"},
+			{Name: "Port", Type: "string", Env: "METRICS_BIND_PORT", Default: "9100"},
+		},
+	},
+	{
+		Name:     TracingSection,
+		FileName: "tracing.go",
+		Comment:  "selects where spans are exported to, the remaining OTEL_* variables are read by the OpenTelemetry SDK",
+		Fields: []ConfigField{
+			{Name: "ServiceName", Type: "string", Env: "OTEL_SERVICE_NAME", Comment: "spans are reported under the project name when it is not set"},
+			{Name: "Exporter", Type: "string", Env: "OTEL_TRACES_EXPORTER", Default: "otlp", Comment: "otlp, console or none"},
+			{Name: "Endpoint", Type: "string", Env: "OTEL_EXPORTER_OTLP_ENDPOINT", Comment: "read by the otlp exporter, http://localhost:4318 when it is not set"},
+			{Name: "Sampler", Type: "string", Env: "OTEL_TRACES_SAMPLER", Comment: "read by the SDK, parentbased_always_on when it is not set"},
+		},
+	},
+}
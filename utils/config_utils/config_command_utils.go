@@ -0,0 +1,139 @@
+package config_utils
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/templates"
+	"github.com/davidh16/goblin/utils"
+	"github.com/samber/lo"
+	"go/format"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// ConfigPackage returns the package name of the generated config package
+func ConfigPackage() string {
+	return strings.Split(cli_config.CliConfig.ConfigFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ConfigFolderPath, "/"))-1]
+}
+
+// ConfigPackageImport returns the import path of the generated config package
+func ConfigPackageImport() string {
+	return path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ConfigFolderPath)
+}
+
+// SectionImplemented reports whether the section has been added to the generated Config
+func SectionImplemented(sectionName string) bool {
+	section, exists := lo.Find(ConfigSections, func(section ConfigSection) bool {
+		return section.Name == sectionName
+	})
+	return exists && utils.FileExists(path.Join(cli_config.CliConfig.ConfigFolderPath, section.FileName))
+}
+
+// GenerateConfig adds the sections to the generated Config, sections added by other generators are kept.
+// The sections are rewritten every time, so renamed variables are picked up by regenerating them.
+func GenerateConfig(sectionNames ...string) error {
+	err := os.MkdirAll(cli_config.CliConfig.ConfigFolderPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	for _, sectionName := range sectionNames {
+		section, exists := lo.Find(ConfigSections, func(section ConfigSection) bool {
+			return section.Name == sectionName
+		})
+		if !exists {
+			return fmt.Errorf("unknown config section %s", sectionName)
+		}
+
+		sectionData := struct {
+			ConfigPackage string
+			Section       ConfigSection
+			UsesTime      bool
+		}{
+			ConfigPackage: ConfigPackage(),
+			Section:       section,
+			UsesTime: lo.ContainsBy(section.Fields, func(field ConfigField) bool {
+				return strings.HasPrefix(field.Type, "time.")
+			}),
+		}
+
+		err = renderTemplate(ConfigSectionTemplatePath, path.Join(cli_config.CliConfig.ConfigFolderPath, section.FileName), sectionData)
+		if err != nil {
+			return err
+		}
+	}
+
+	secretsData := struct {
+		ConfigPackage             string
+		EncryptionKeyVariable     string
+		EncryptionKeyFileVariable string
+		EncryptionKeyFileName     string
+		EncryptedFileSuffix       string
+		EncryptedValuePrefix      string
+	}{
+		ConfigPackage:             ConfigPackage(),
+		EncryptionKeyVariable:     EncryptionKeyVariable,
+		EncryptionKeyFileVariable: EncryptionKeyFileVariable,
+		EncryptionKeyFileName:     EncryptionKeyFileName,
+		EncryptedFileSuffix:       EncryptedFileSuffix,
+		EncryptedValuePrefix:      EncryptedValuePrefix,
+	}
+
+	// values of the .env.enc files are decrypted by Load
+	err = renderTemplate(ConfigSecretsTemplatePath, path.Join(cli_config.CliConfig.ConfigFolderPath, ConfigSecretsFileName), secretsData)
+	if err != nil {
+		return err
+	}
+
+	configData := struct {
+		ConfigPackage       string
+		ProfileVariable     string
+		EncryptedFileSuffix string
+		Sections            []ConfigSection
+	}{
+		ConfigPackage:       ConfigPackage(),
+		ProfileVariable:     ProfileVariable,
+		EncryptedFileSuffix: EncryptedFileSuffix,
+		Sections: lo.Filter(ConfigSections, func(section ConfigSection, _ int) bool {
+			return utils.FileExists(path.Join(cli_config.CliConfig.ConfigFolderPath, section.FileName))
+		}),
+	}
+
+	return renderTemplate(ConfigTemplatePath, path.Join(cli_config.CliConfig.ConfigFolderPath, ConfigFileName), configData)
+}
+
+// renderTemplate renders the template formatted, the section structs have their tags aligned
+func renderTemplate(templatePath, filePath string, templateData any) error {
+	tmpl, err := template.ParseFS(templates.Files, templatePath)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, templateData)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", filePath, err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", filePath, err)
+	}
+
+	return os.WriteFile(filePath, formatted, 0644)
+}
+
+// DefaultValue returns the default of the variable declared by the sections, empty when it has none
+func DefaultValue(env string) string {
+	for _, section := range ConfigSections {
+		for _, field := range section.Fields {
+			if field.Env == env {
+				return field.Default
+			}
+		}
+	}
+	return ""
+}
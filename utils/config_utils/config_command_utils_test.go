@@ -0,0 +1,110 @@
+package config_utils
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/samber/lo"
+)
+
+func TestConfigSectionsDeclareEveryVariableOnce(t *testing.T) {
+	declaredBy := map[string]string{}
+	for _, section := range ConfigSections {
+		for _, field := range section.Fields {
+			if otherSection, exists := declaredBy[field.Env]; exists {
+				t.Errorf("%s is declared by both %s and %s", field.Env, otherSection, section.Name)
+			}
+			declaredBy[field.Env] = section.Name
+		}
+	}
+}
+
+// the listeners of a project run in one process, two of them defaulting to the same port fail on startup
+func TestBindPortDefaultsAreDistinct(t *testing.T) {
+	usedBy := map[string]string{}
+	for _, section := range ConfigSections {
+		for _, field := range section.Fields {
+			if !strings.HasSuffix(field.Env, "_BIND_PORT") {
+				continue
+			}
+			if field.Default == "" {
+				t.Errorf("%s has no default", field.Env)
+				continue
+			}
+			if otherEnv, exists := usedBy[field.Default]; exists {
+				t.Errorf("%s and %s both default to port %s", otherEnv, field.Env, field.Default)
+			}
+			usedBy[field.Default] = field.Env
+		}
+	}
+}
+
+func TestDefaultValue(t *testing.T) {
+	for env, want := range map[string]string{
+		"SERVER_BIND_PORT":  "8080",
+		"GRPC_BIND_PORT":    "50051",
+		"METRICS_BIND_PORT": "9100",
+		"RATE_LIMIT_WINDOW": "1m",
+		"POSTGRES_USER":     "",
+		"UNKNOWN_VARIABLE":  "",
+	} {
+		if got := DefaultValue(env); got != want {
+			t.Errorf("DefaultValue(%s) = %q, want %q", env, got, want)
+		}
+	}
+}
+
+// TestGeneratedConfigLoad generates the config with every section and runs the tests of testdata/config against it.
+// The dependencies of the generated project are downloaded, so it runs as an integration test only, with GOBLIN_INTEGRATION set.
+func TestGeneratedConfigLoad(t *testing.T) {
+	if os.Getenv("GOBLIN_INTEGRATION") == "" {
+		t.Skip("generating and testing a project downloads its dependencies, set GOBLIN_INTEGRATION=1 to run it")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+
+	fixture, err := os.ReadFile(path.Join("testdata", "config", "config_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+
+	if err = os.WriteFile("go.mod", []byte("module example.com/app\n\ngo 1.24\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	previousConfig := cli_config.CliConfig
+	t.Cleanup(func() { cli_config.CliConfig = previousConfig })
+	cli_config.CliConfig = &cli_config.Config{ProjectName: "example.com/app", ConfigFolderPath: "config"}
+
+	sectionNames := lo.Map(ConfigSections, func(section ConfigSection, _ int) string {
+		return section.Name
+	})
+	if err = GenerateConfig(sectionNames...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(path.Join(projectDir, "config", "config_test.go"), fixture, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"mod", "tidy"},
+		{"vet", "./..."},
+		{"test", "./config/"},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = projectDir
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go %s: %s\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package config
+
+// This file is copied into the config package generated by TestGeneratedConfigLoad with every section added.
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useEnv runs the test in an empty folder, so no .env file is loaded, with the required variables set.
+// Variables set by the .env files are restored afterward like the ones set with t.Setenv.
+func useEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+
+	t.Chdir(t.TempDir())
+
+	for _, key := range []string{"APP_ENV", "ENV_ENCRYPTION_KEY", "ENV_ENCRYPTION_KEY_FILE", "GRPC_BIND_PORT", "RATE_LIMIT_WINDOW", "ALLOW_ORIGINS"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	required := map[string]string{
+		"POSTGRES_USER": "app",
+		"POSTGRES_DB":   "app",
+		"MARIADB_USER":  "app",
+		"MARIADB_DB":    "app",
+	}
+	for key, value := range required {
+		t.Setenv(key, value)
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	useEnv(t, nil)
+
+	config, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, field := range []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "SERVER_BIND_PORT", got: config.Server.Port, want: "8080"},
+		{name: "GRPC_BIND_PORT", got: config.Grpc.Port, want: "50051"},
+		{name: "METRICS_BIND_PORT", got: config.Metrics.Port, want: "9100"},
+		{name: "REDIS_DB", got: config.Redis.Db, want: 0},
+		{name: "RATE_LIMIT_WINDOW", got: config.RateLimit.Window, want: time.Minute},
+		{name: "RATE_LIMIT_FAIL_OPEN", got: config.RateLimit.FailOpen, want: true},
+		{name: "JWT_REFRESH_TOKEN_TTL", got: config.Jwt.RefreshTokenTtl, want: 720 * time.Hour},
+	} {
+		if field.got != field.want {
+			t.Errorf("%s = %v, want the default %v", field.name, field.got, field.want)
+		}
+	}
+
+	if Get() != config {
+		t.Error("Get doesn't return the config loaded by Load")
+	}
+}
+
+func TestLoadParsesVariables(t *testing.T) {
+	useEnv(t, map[string]string{
+		"RATE_LIMIT_LIMIT":     "5",
+		"RATE_LIMIT_WINDOW":    "90s",
+		"RATE_LIMIT_FAIL_OPEN": "false",
+		"ALLOW_ORIGINS":        " https://example.com, ,https://admin.example.com ",
+		"REDIS_DB":             "2",
+	})
+
+	config, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.RateLimit.Limit != 5 || config.RateLimit.Window != 90*time.Second || config.RateLimit.FailOpen {
+		t.Errorf("rate limit = %+v, want 5 requests per 90s failing closed", config.RateLimit)
+	}
+	if want := []string{"https://example.com", "https://admin.example.com"}; !reflect.DeepEqual(config.Cors.AllowOrigins, want) {
+		t.Errorf("ALLOW_ORIGINS = %q, want %q", config.Cors.AllowOrigins, want)
+	}
+	if config.Redis.Db != 2 {
+		t.Errorf("REDIS_DB = %d, want 2", config.Redis.Db)
+	}
+}
+
+func TestLoadReportsInvalidValues(t *testing.T) {
+	useEnv(t, map[string]string{"RATE_LIMIT_WINDOW": "soon"})
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), "RATE_LIMIT_WINDOW") {
+		t.Errorf("Load() error = %v, want the invalid RATE_LIMIT_WINDOW", err)
+	}
+}
+
+func TestLoadReportsMissingRequiredVariables(t *testing.T) {
+	useEnv(t, map[string]string{"POSTGRES_USER": "", "MARIADB_DB": ""})
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("Load() succeeded without the required variables")
+	}
+	for _, key := range []string{"POSTGRES_USER", "MARIADB_DB"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("Load() error = %v, want %s listed", err, key)
+		}
+	}
+}
+
+func TestLoadEnvFilesPrecedence(t *testing.T) {
+	useEnv(t, nil)
+
+	if err := os.WriteFile(".env", []byte("GRPC_BIND_PORT=6000\nRATE_LIMIT_WINDOW=2m\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(".env.staging", []byte("GRPC_BIND_PORT=7000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_ENV", "staging")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Grpc.Port != "7000" {
+		t.Errorf("GRPC_BIND_PORT = %s, want 7000 of .env.staging", config.Grpc.Port)
+	}
+	if config.RateLimit.Window != 2*time.Minute {
+		t.Errorf("RATE_LIMIT_WINDOW = %s, want 2m of .env", config.RateLimit.Window)
+	}
+
+	// variables set in the environment take precedence over the files
+	t.Setenv("GRPC_BIND_PORT", "8000")
+	config, err = Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Grpc.Port != "8000" {
+		t.Errorf("GRPC_BIND_PORT = %s, want 8000 of the environment", config.Grpc.Port)
+	}
+}
+
+func TestStringRedactsSecrets(t *testing.T) {
+	useEnv(t, map[string]string{"POSTGRES_PASSWORD": "hunter2"})
+
+	config, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dumped := config.String()
+	if strings.Contains(dumped, "hunter2") || !strings.Contains(dumped, "POSTGRES_PASSWORD="+redactedValue) {
+		t.Errorf("POSTGRES_PASSWORD isn't redacted:\n%s", dumped)
+	}
+	if !strings.Contains(dumped, "GRPC_BIND_PORT=50051") {
+		t.Errorf("GRPC_BIND_PORT isn't listed:\n%s", dumped)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/davidh16/goblin/cli_config"
 	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/config_utils"
+	"github.com/davidh16/goblin/utils/observability_utils"
 	"os"
 	"path"
 	"strings"
@@ -46,6 +48,12 @@ var DatabaseOptionInstanceDefaultFileNamesMap = map[DatabaseOption]string{
 	Redis:       "redis.go",
 }
 
+var DatabaseOptionConfigSectionsMap = map[DatabaseOption]string{
+	PostgresSQL: config_utils.PostgresSection,
+	MariaDB:     config_utils.MariaDBSection,
+	Redis:       config_utils.RedisSection,
+}
+
 func GetDatabaseOptionDefaultEnvDataMap(option DatabaseOption) (map[string]string, error) {
 	switch option {
 	case Unspecified:
@@ -68,9 +76,8 @@ func GetDatabaseOptionDefaultEnvDataMap(option DatabaseOption) (map[string]strin
 		}, nil
 	case Redis:
 		return map[string]string{
-			"REDIS_USER":     "redis",
 			"REDIS_PASSWORD": "redis",
-			"REDIS_DB":       cli_config.CliConfig.ProjectName,
+			"REDIS_DB":       "0",
 			"REDIS_HOST":     "localhost",
 			"REDIS_PORT":     DatabaseOptionDefaultPortsMap[Redis],
 		}, nil
@@ -108,9 +115,16 @@ func InitializeDatabaseInstance(database DatabaseData) error {
 	defer file.Close()
 
 	templateData := struct {
-		DatabasePackage string
+		DatabasePackage     string
+		TracingImplemented  bool
+		ConfigPackage       string
+		ConfigPackageImport string
 	}{
 		DatabasePackage: strings.Split(cli_config.CliConfig.DatabaseInstancesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.DatabaseInstancesFolderPath, "/"))-1],
+		// connectors generated after goblin observability record queries and commands as spans
+		TracingImplemented:  observability_utils.TracingImplemented(),
+		ConfigPackage:       config_utils.ConfigPackage(),
+		ConfigPackageImport: config_utils.ConfigPackageImport(),
 	}
 
 	err = tmpl.Execute(file, templateData)
@@ -118,6 +132,12 @@ func InitializeDatabaseInstance(database DatabaseData) error {
 		return err
 	}
 
+	// the connector reads its connection details from the config section of the database
+	err = config_utils.GenerateConfig(DatabaseOptionConfigSectionsMap[database.DatabaseType])
+	if err != nil {
+		return err
+	}
+
 	if database.DatabaseType != Redis && !utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, "pagination.go")) {
 		err = generatePaginationFile()
 		if err != nil {
@@ -183,3 +203,12 @@ func GetSortedDatabaseOptionsWithoutRedis() []string {
 	}
 	return sortedDatabaseOptions
 }
+
+// JobsDatabaseIsMariaDB reports whether the jobs manager saves failed jobs to MariaDB.
+// main.go passes it the PostgreSQL connection whenever the project has one, i.e through the central repository, and MariaDB otherwise.
+func JobsDatabaseIsMariaDB() bool {
+	postgresConnected := utils.FileExists(path.Join(cli_config.CliConfig.RepositoriesFolderPath, "central_repo.go")) ||
+		utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, DatabaseOptionInstanceDefaultFileNamesMap[PostgresSQL]))
+
+	return !postgresConnected && utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, DatabaseOptionInstanceDefaultFileNamesMap[MariaDB]))
+}
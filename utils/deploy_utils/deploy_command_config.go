@@ -0,0 +1,25 @@
+package deploy_utils
+
+const (
+	K8sFolderPath = "deploy/k8s"
+
+	K8sKustomizationFileName       = "kustomization.yaml"
+	K8sConfigMapFileName           = "configmap.yaml"
+	K8sSecretFileName              = "secret.yaml"
+	K8sDeploymentFileName          = "deployment.yaml"
+	K8sWorkerDeploymentFileName    = "worker-deployment.yaml"
+	K8sServiceFileName             = "service.yaml"
+	K8sMigrationsConfigMapFileName = "migrations-configmap.yaml"
+	K8sMigrationJobFileName        = "migration-job.yaml"
+
+	K8sKustomizationTemplatePath       = "k8s_kustomization.tmpl"
+	K8sConfigMapTemplatePath           = "k8s_configmap.tmpl"
+	K8sSecretTemplatePath              = "k8s_secret.tmpl"
+	K8sDeploymentTemplatePath          = "k8s_deployment.tmpl"
+	K8sServiceTemplatePath             = "k8s_service.tmpl"
+	K8sMigrationsConfigMapTemplatePath = "k8s_migrations_configmap.tmpl"
+	K8sMigrationJobTemplatePath        = "k8s_migration_job.tmpl"
+)
+
+// secretKeyParts mark variables of .env which are not declared by the config sections as secrets
+var secretKeyParts = []string{"PASSWORD", "SECRET", "TOKEN", "PRIVATE_KEY", "API_KEY"}
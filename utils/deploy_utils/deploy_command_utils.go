@@ -0,0 +1,280 @@
+package deploy_utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/davidh16/goblin/templates"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/config_utils"
+	"github.com/davidh16/goblin/utils/docker_utils"
+	"github.com/samber/lo"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// nonNameCharactersRegex matches the characters which are not allowed in the names of Kubernetes objects
+var nonNameCharactersRegex = regexp.MustCompile(`[^a-z0-9-]+`)
+
+type K8sEnvValue struct {
+	Key   string
+	Value string
+}
+
+type K8sFile struct {
+	Name    string
+	Content string
+}
+
+// K8sData describes the project the manifests are generated for, the stack is detected the same way goblin docker does
+type K8sData struct {
+	*docker_utils.DockerData
+
+	Name       string
+	Image      string
+	FolderPath string
+
+	ConfigValues []K8sEnvValue
+	SecretKeys   []string
+
+	MigrateScript string
+	Migrations    []K8sFile
+}
+
+// k8sManifest is a file of deploy/k8s and the template it is rendered from
+type k8sManifest struct {
+	FileName     string
+	TemplatePath string
+	Data         any
+}
+
+// k8sDeploymentData is a Deployment of the app, the api and the workers run the same image
+type k8sDeploymentData struct {
+	*K8sData
+
+	DeploymentName string
+	Component      string
+	Args           []string
+	ServesGrpc     bool
+}
+
+// DetectK8sData splits the .env values into the ConfigMap and the Secret and reads the migrations for the migration job.
+// Variables of the generated config which .env lacks are added with their defaults.
+func DetectK8sData() (*K8sData, error) {
+	dockerData, err := docker_utils.DetectDockerData()
+	if err != nil {
+		return nil, err
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	var envLines []string
+	if utils.FileExists(path.Join(workingDirectory, ".env")) {
+		content, err := os.ReadFile(path.Join(workingDirectory, ".env"))
+		if err != nil {
+			return nil, errors.New("error opening environment file")
+		}
+		envLines = strings.Split(string(content), "\n")
+	}
+
+	name := strings.Trim(nonNameCharactersRegex.ReplaceAllString(strings.ToLower(dockerData.BinaryName), "-"), "-")
+	k8sData := &K8sData{
+		DockerData: dockerData,
+		Name:       name,
+		Image:      fmt.Sprintf("%s:latest", name),
+		FolderPath: K8sFolderPath,
+	}
+
+	envValues := make(map[string]string)
+	var envKeys []string
+	for _, line := range envLines {
+		if key, value, isVariable := utils.ParseEnvLine(line); isVariable {
+			envValues[key] = strings.Trim(strings.TrimSpace(value), `"'`)
+			envKeys = append(envKeys, key)
+		}
+	}
+
+	// servers listen on all interfaces inside the pod, whatever .env binds them to locally
+	envValues["SERVER_BIND_ADDRESS"] = "0.0.0.0"
+	envValues["GRPC_BIND_ADDRESS"] = "0.0.0.0"
+	envValues["METRICS_BIND_ADDRESS"] = "0.0.0.0"
+
+	added := lo.SliceToMap(config_utils.LoaderVariables, func(variable string) (string, bool) {
+		return variable, true
+	})
+	addValue := func(key string, secret bool) {
+		if added[key] {
+			return
+		}
+		added[key] = true
+
+		if secret {
+			k8sData.SecretKeys = append(k8sData.SecretKeys, key)
+			return
+		}
+
+		value, exists := envValues[key]
+		if !exists {
+			value = config_utils.DefaultValue(key)
+		}
+		k8sData.ConfigValues = append(k8sData.ConfigValues, K8sEnvValue{Key: key, Value: value})
+	}
+
+	for _, section := range config_utils.ConfigSections {
+		if !config_utils.SectionImplemented(section.Name) {
+			continue
+		}
+		for _, field := range section.Fields {
+			addValue(field.Env, field.Secret)
+		}
+	}
+
+	for _, key := range envKeys {
+		if key == "SERVER_BIND_ADDRESS" || key == "GRPC_BIND_ADDRESS" || key == "METRICS_BIND_ADDRESS" {
+			continue
+		}
+		addValue(key, isSecretKey(key))
+	}
+
+	if !dockerData.MigrationsImplemented {
+		return k8sData, nil
+	}
+
+	// the migration job reads the connection from the ConfigMap and the Secret
+	for _, field := range lo.Must(lo.Find(config_utils.ConfigSections, func(section config_utils.ConfigSection) bool {
+		return section.Name == config_utils.PostgresSection
+	})).Fields {
+		addValue(field.Env, field.Secret)
+	}
+
+	migrateScript, err := renderTemplate(docker_utils.DockerFileTemplatePathMap[docker_utils.MigrateScriptPath], dockerData)
+	if err != nil {
+		return nil, err
+	}
+	k8sData.MigrateScript = migrateScript
+
+	migrationPaths, err := filepath.Glob(path.Join(dockerData.MigrationsFolderPath, docker_utils.MigrationsUpFileGlob))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migrationPath := range migrationPaths {
+		content, err := os.ReadFile(migrationPath)
+		if err != nil {
+			return nil, err
+		}
+		k8sData.Migrations = append(k8sData.Migrations, K8sFile{Name: filepath.Base(migrationPath), Content: string(content)})
+	}
+
+	return k8sData, nil
+}
+
+func isSecretKey(key string) bool {
+	return lo.ContainsBy(secretKeyParts, func(part string) bool {
+		return strings.Contains(key, part)
+	})
+}
+
+// GenerateK8sManifests renders the manifests into deploy/k8s, together with a kustomization listing them
+func GenerateK8sManifests(k8sData *K8sData) error {
+	err := os.MkdirAll(k8sData.FolderPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	apiDeploymentData := &k8sDeploymentData{K8sData: k8sData, DeploymentName: k8sData.Name, Component: docker_utils.RoleApi, ServesGrpc: true}
+	if k8sData.WorkersImplemented {
+		// the API and the workers are scaled separately, each deployment runs the binary with its role
+		apiDeploymentData.Args = []string{docker_utils.RoleFlag, docker_utils.RoleApi}
+	}
+
+	manifests := []k8sManifest{
+		{K8sConfigMapFileName, K8sConfigMapTemplatePath, k8sData},
+		{K8sSecretFileName, K8sSecretTemplatePath, k8sData},
+		{K8sDeploymentFileName, K8sDeploymentTemplatePath, apiDeploymentData},
+		{K8sServiceFileName, K8sServiceTemplatePath, k8sData},
+	}
+
+	if k8sData.WorkersImplemented {
+		manifests = append(manifests, k8sManifest{K8sWorkerDeploymentFileName, K8sDeploymentTemplatePath, &k8sDeploymentData{
+			K8sData:        k8sData,
+			DeploymentName: k8sData.Name + "-worker",
+			Component:      docker_utils.RoleWorker,
+			Args:           []string{docker_utils.RoleFlag, docker_utils.RoleWorker},
+		}})
+	}
+
+	if k8sData.MigrationsImplemented {
+		manifests = append(manifests,
+			k8sManifest{K8sMigrationsConfigMapFileName, K8sMigrationsConfigMapTemplatePath, k8sData},
+			k8sManifest{K8sMigrationJobFileName, K8sMigrationJobTemplatePath, k8sData},
+		)
+	}
+
+	var fileNames []string
+	for _, manifest := range manifests {
+		content, err := renderTemplate(manifest.TemplatePath, manifest.Data)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(path.Join(k8sData.FolderPath, manifest.FileName), []byte(content), 0644)
+		if err != nil {
+			return err
+		}
+
+		fileNames = append(fileNames, manifest.FileName)
+		fmt.Println(fmt.Sprintf("✅ %s generated successfully.", path.Join(k8sData.FolderPath, manifest.FileName)))
+	}
+
+	kustomization, err := renderTemplate(K8sKustomizationTemplatePath, struct {
+		FolderPath string
+		FileNames  []string
+	}{
+		FolderPath: k8sData.FolderPath,
+		FileNames:  fileNames,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join(k8sData.FolderPath, K8sKustomizationFileName), []byte(kustomization), 0644)
+}
+
+func renderTemplate(templatePath string, templateData any) (string, error) {
+	funcMap := template.FuncMap{
+		// double quoted Go strings are valid double quoted YAML strings
+		"quote": strconv.Quote,
+		"indent": func(spaces int, text string) string {
+			padding := strings.Repeat(" ", spaces)
+			lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+			for i, line := range lines {
+				if line != "" {
+					lines[i] = padding + line
+				}
+			}
+			return strings.Join(lines, "\n")
+		},
+	}
+
+	tmpl, err := template.New(path.Base(templatePath)).Funcs(funcMap).ParseFS(templates.Files, templatePath)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, templateData)
+	if err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", templatePath, err)
+	}
+
+	return buf.String(), nil
+}
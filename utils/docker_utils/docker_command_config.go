@@ -0,0 +1,27 @@
+package docker_utils
+
+const (
+	DockerfileName       = "Dockerfile"
+	DockerComposeName    = "docker-compose.yml"
+	DockerIgnoreName     = ".dockerignore"
+	MigrateScriptPath    = "scripts/migrate.sh"
+	DefaultGoVersion     = "1.24"
+	MigrationsUpFileGlob = "*_up.sql"
+)
+
+// RoleFlag selects the components the binary runs, main.go takes it once the workers are connected
+const (
+	RoleFlag   = "--role"
+	RoleApi    = "api"
+	RoleWorker = "worker"
+)
+
+// DockerFiles lists the generated files in the order they are generated in
+var DockerFiles = []string{DockerfileName, DockerIgnoreName, DockerComposeName, MigrateScriptPath}
+
+var DockerFileTemplatePathMap = map[string]string{
+	DockerfileName:    "dockerfile.tmpl",
+	DockerComposeName: "docker_compose.tmpl",
+	DockerIgnoreName:  "dockerignore.tmpl",
+	MigrateScriptPath: "migrate_script.tmpl",
+}
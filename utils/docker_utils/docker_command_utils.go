@@ -0,0 +1,169 @@
+package docker_utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/templates"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/config_utils"
+	"github.com/davidh16/goblin/utils/initialize_utils"
+	"github.com/davidh16/goblin/utils/middleware_utils"
+	"github.com/davidh16/goblin/utils/router_utils"
+	"github.com/samber/lo"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// DockerData describes the stack of the project, the generated files only contain the services it uses
+type DockerData struct {
+	GoVersion  string
+	BinaryName string
+
+	ServerPort  string
+	GrpcPort    string
+	MetricsPort string
+
+	GrpcImplemented       bool
+	MetricsImplemented    bool
+	WorkersImplemented    bool
+	RateLimitsImplemented bool
+	RateLimitsFileName    string
+
+	PostgresImplemented bool
+	MariaDBImplemented  bool
+	RedisImplemented    bool
+
+	// migrations are written for PostgreSQL, so they are only applied when the project connects to it
+	MigrationsImplemented bool
+	MigrationsFolderPath  string
+	MigrateScriptPath     string
+
+	KeysImplemented bool
+	KeysFolderPath  string
+}
+
+// DetectDockerData inspects the generated components and reads the ports from .env, falling back to the config defaults
+func DetectDockerData() (*DockerData, error) {
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string)
+	if utils.FileExists(path.Join(workingDirectory, ".env")) {
+		envFile, err := os.Open(path.Join(workingDirectory, ".env"))
+		if err != nil {
+			return nil, errors.New("error opening environment file")
+		}
+		defer envFile.Close()
+
+		env, err = utils.ReadEnvFile(envFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	envValue := func(key string) string {
+		return lo.CoalesceOrEmpty(strings.TrimSpace(env[key]), config_utils.DefaultValue(key))
+	}
+
+	goVersion, err := projectGoVersion(path.Join(workingDirectory, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	mainData := initialize_utils.DetectMainData()
+
+	migrations, err := filepath.Glob(path.Join(cli_config.CliConfig.MigrationsFolderPath, MigrationsUpFileGlob))
+	if err != nil {
+		return nil, err
+	}
+
+	dockerData := &DockerData{
+		GoVersion:  goVersion,
+		BinaryName: path.Base(cli_config.CliConfig.ProjectName),
+
+		ServerPort:  envValue("SERVER_BIND_PORT"),
+		GrpcPort:    envValue("GRPC_BIND_PORT"),
+		MetricsPort: envValue("METRICS_BIND_PORT"),
+
+		GrpcImplemented:       mainData.GrpcImplemented && mainData.ImplementCentralService,
+		MetricsImplemented:    mainData.MetricsImplemented,
+		WorkersImplemented:    mainData.WorkersConnected(), // the API and the workers run as separate services once main.go starts the orchestrator
+		RateLimitsImplemented: utils.FileExists(path.Join(workingDirectory, middleware_utils.RateLimitsConfigFileName)),
+		RateLimitsFileName:    middleware_utils.RateLimitsConfigFileName,
+
+		PostgresImplemented: mainData.ImplementCentralRepository || mainData.PostgresImplemented,
+		MariaDBImplemented:  mainData.MariaDBImplemented,
+		// the jobs queue of the workers is kept in Redis as well
+		RedisImplemented: mainData.RedisImplemented || mainData.WorkersImplemented || router_utils.RouterRequiresRedis(),
+
+		MigrationsFolderPath: strings.TrimSuffix(cli_config.CliConfig.MigrationsFolderPath, "/"),
+		MigrateScriptPath:    MigrateScriptPath,
+
+		KeysImplemented: utils.FileExists(cli_config.CliConfig.KeysFolderPath),
+		KeysFolderPath:  strings.TrimSuffix(cli_config.CliConfig.KeysFolderPath, "/"),
+	}
+	dockerData.MigrationsImplemented = dockerData.PostgresImplemented && len(migrations) > 0
+
+	return dockerData, nil
+}
+
+// projectGoVersion returns the go directive of go.mod, the build stage uses the matching golang image
+func projectGoVersion(goModPath string) (string, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "go ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "go ")), nil
+		}
+	}
+
+	return DefaultGoVersion, scanner.Err()
+}
+
+// GenerateDockerFiles renders the files, the migrate script is only generated when there are migrations to apply
+func GenerateDockerFiles(fileNames []string, dockerData *DockerData) error {
+	for _, fileName := range fileNames {
+		if fileName == MigrateScriptPath && !dockerData.MigrationsImplemented {
+			continue
+		}
+
+		tmpl, err := template.ParseFS(templates.Files, DockerFileTemplatePathMap[fileName])
+		if err != nil {
+			return err
+		}
+
+		err = os.MkdirAll(path.Dir(fileName), os.ModePerm)
+		if err != nil {
+			return err
+		}
+
+		// the migrate script is run by sh, the mode only allows running it directly as well
+		f, err := os.OpenFile(fileName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, lo.Ternary[os.FileMode](fileName == MigrateScriptPath, 0755, 0644))
+		if err != nil {
+			return err
+		}
+
+		err = tmpl.Execute(f, dockerData)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", fileName, err)
+		}
+
+		fmt.Println(fmt.Sprintf("✅ %s generated successfully.", fileName))
+	}
+
+	return nil
+}
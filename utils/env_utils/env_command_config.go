@@ -0,0 +1,41 @@
+package env_utils
+
+const (
+	EnvFileName        = ".env"
+	EnvExampleFileName = ".env.example"
+)
+
+const (
+	ProfileDev  = "dev"
+	ProfileTest = "test"
+	ProfileProd = "prod"
+)
+
+var ProfileOptions = []string{ProfileDev, ProfileTest, ProfileProd}
+
+// nonceSize is the size of the random nonce every encrypted value starts with
+const nonceSize = 24
+
+// ignoredDirectories are not scanned for environment variables
+var ignoredDirectories = []string{"vendor", "node_modules", "testdata"}
+
+// EnvUsage is a variable read by the project, through os.Getenv, os.LookupEnv, config.Lookup or an env struct tag
+type EnvUsage struct {
+	// Key is a pattern when the name is built at runtime, i.e RATE_LIMIT_*_LIMIT
+	Key       string
+	Dynamic   bool
+	Default   string
+	Required  bool
+	Positions []string
+}
+
+// EnvReport compares the variables read by the project with the ones set in an env file
+type EnvReport struct {
+	EnvFileName string
+	// Missing are read without a default but not set, or required and set empty
+	Missing []EnvUsage
+	// Unused are set but never read
+	Unused []string
+	// Dynamic are read by names built at runtime, matching keys are not reported as unused
+	Dynamic []EnvUsage
+}
@@ -0,0 +1,842 @@
+package env_utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/config_utils"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"golang.org/x/crypto/nacl/secretbox"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatVerbRegex matches the verbs of a fmt.Sprintf format, they are replaced by * in the key pattern
+var formatVerbRegex = regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z]`)
+
+// envGroup is a block of variables in .env.example and in the synced env files
+type envGroup struct {
+	Comment string
+	Fields  []envField
+}
+
+type envField struct {
+	Key     string
+	Value   string
+	Comment string
+}
+
+// EnvFilePath returns the env file of the profile, .env when no profile is selected
+func EnvFilePath(profile string) string {
+	if profile == "" {
+		return EnvFileName
+	}
+	return fmt.Sprintf("%s.%s", EnvFileName, profile)
+}
+
+// ValidateProfile checks the profile is one of ProfileOptions, an empty profile selects .env
+func ValidateProfile(profile string) error {
+	if profile != "" && !lo.Contains(ProfileOptions, profile) {
+		return fmt.Errorf("unknown profile %s, expected one of %s", profile, strings.Join(ProfileOptions, ", "))
+	}
+	return nil
+}
+
+// ScanEnvUsages parses the go files of the project and returns the variables they read, sorted by key.
+// Variables are read through os.Getenv, os.LookupEnv, Lookup of the generated config package or declared by env struct tags.
+func ScanEnvUsages(root string) ([]EnvUsage, error) {
+	usages := make(map[string]*EnvUsage)
+	fileSet := token.NewFileSet()
+
+	addUsage := func(key string, position token.Pos, defaultValue string, required bool) {
+		// the loader of the config package reads the variables of the struct tags, it has no key of its own
+		if strings.Trim(key, "*") == "" {
+			return
+		}
+
+		usage, exists := usages[key]
+		if !exists {
+			usage = &EnvUsage{Key: key, Dynamic: strings.Contains(key, "*")}
+			usages[key] = usage
+		}
+
+		if defaultValue != "" {
+			usage.Default = defaultValue
+		}
+		usage.Required = usage.Required || required
+
+		filePosition := fileSet.Position(position)
+		relativePath, err := filepath.Rel(root, filePosition.Filename)
+		if err != nil {
+			relativePath = filePosition.Filename
+		}
+		usage.Positions = append(usage.Positions, fmt.Sprintf("%s:%d", filepath.ToSlash(relativePath), filePosition.Line))
+	}
+
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if filePath != root && (strings.HasPrefix(entry.Name(), ".") || lo.Contains(ignoredDirectories, entry.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// tests set the variables they need themselves
+		if !strings.HasSuffix(filePath, ".go") || strings.HasSuffix(filePath, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fileSet, filePath, nil, 0)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filePath, err)
+		}
+
+		osName := importName(file, "os")
+		configName := importName(file, config_utils.ConfigPackageImport())
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.CallExpr:
+				selector, isSelector := node.Fun.(*ast.SelectorExpr)
+				if !isSelector || len(node.Args) == 0 {
+					return true
+				}
+
+				packageIdent, isIdent := selector.X.(*ast.Ident)
+				if !isIdent {
+					return true
+				}
+
+				readsEnv := packageIdent.Name == osName && (selector.Sel.Name == "Getenv" || selector.Sel.Name == "LookupEnv")
+				readsConfig := packageIdent.Name == configName && selector.Sel.Name == "Lookup"
+				if readsEnv || readsConfig {
+					addUsage(resolveKey(node.Args[0], 0), node.Pos(), "", false)
+				}
+			case *ast.Field:
+				if node.Tag == nil {
+					return true
+				}
+
+				tag, err := strconv.Unquote(node.Tag.Value)
+				if err != nil {
+					return true
+				}
+
+				structTag := reflect.StructTag(tag)
+				envTag, hasEnvTag := structTag.Lookup("env")
+				if !hasEnvTag {
+					return true
+				}
+
+				// i.e env:"PORT,required" of caarlos0/env
+				tagParts := strings.Split(envTag, ",")
+				if tagParts[0] == "" || tagParts[0] == "-" {
+					return true
+				}
+
+				defaultValue := lo.CoalesceOrEmpty(structTag.Get("default"), structTag.Get("envDefault"))
+				required := structTag.Get("required") == "true" || lo.Contains(tagParts[1:], "required")
+				addUsage(tagParts[0], node.Pos(), defaultValue, required)
+			}
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortedUsages := lo.MapToSlice(usages, func(_ string, usage *EnvUsage) EnvUsage {
+		return *usage
+	})
+	sort.Slice(sortedUsages, func(i, j int) bool {
+		return sortedUsages[i].Key < sortedUsages[j].Key
+	})
+
+	return sortedUsages, nil
+}
+
+// importName returns the name the file refers to the imported package by, empty when it is not imported
+func importName(file *ast.File, importPath string) string {
+	for _, fileImport := range file.Imports {
+		if strings.Trim(fileImport.Path.Value, `"`) != importPath {
+			continue
+		}
+		if fileImport.Name != nil {
+			return fileImport.Name.Name
+		}
+		return path.Base(importPath)
+	}
+	return ""
+}
+
+// resolveKey returns the key read by the expression, the parts only known at runtime are replaced by *,
+// i.e "RATE_LIMIT_" + strings.ToUpper(group) + "_LIMIT" is resolved to RATE_LIMIT_*_LIMIT
+func resolveKey(expression ast.Expr, depth int) string {
+	// constants referring to each other are followed a few levels deep
+	if depth > 5 {
+		return "*"
+	}
+
+	switch expression := expression.(type) {
+	case *ast.BasicLit:
+		if expression.Kind != token.STRING {
+			return "*"
+		}
+		value, err := strconv.Unquote(expression.Value)
+		if err != nil {
+			return "*"
+		}
+		return value
+	case *ast.ParenExpr:
+		return resolveKey(expression.X, depth+1)
+	case *ast.BinaryExpr:
+		if expression.Op != token.ADD {
+			return "*"
+		}
+		return collapseWildcards(resolveKey(expression.X, depth+1) + resolveKey(expression.Y, depth+1))
+	case *ast.CallExpr:
+		selector, isSelector := expression.Fun.(*ast.SelectorExpr)
+		if !isSelector || selector.Sel.Name != "Sprintf" || len(expression.Args) == 0 {
+			return "*"
+		}
+		format, isLiteral := expression.Args[0].(*ast.BasicLit)
+		if !isLiteral || format.Kind != token.STRING {
+			return "*"
+		}
+		value, err := strconv.Unquote(format.Value)
+		if err != nil {
+			return "*"
+		}
+		return collapseWildcards(formatVerbRegex.ReplaceAllString(value, "*"))
+	case *ast.Ident:
+		if expression.Obj == nil {
+			return "*"
+		}
+
+		switch declaration := expression.Obj.Decl.(type) {
+		case *ast.ValueSpec:
+			for i, name := range declaration.Names {
+				if name.Name == expression.Name && i < len(declaration.Values) {
+					return resolveKey(declaration.Values[i], depth+1)
+				}
+			}
+		case *ast.AssignStmt:
+			if len(declaration.Lhs) != len(declaration.Rhs) {
+				return "*"
+			}
+			for i, lhs := range declaration.Lhs {
+				if ident, isIdent := lhs.(*ast.Ident); isIdent && ident.Name == expression.Name {
+					return resolveKey(declaration.Rhs[i], depth+1)
+				}
+			}
+		}
+	}
+
+	return "*"
+}
+
+func collapseWildcards(key string) string {
+	for strings.Contains(key, "**") {
+		key = strings.ReplaceAll(key, "**", "*")
+	}
+	return key
+}
+
+// readEnvLines reads the lines of the env file, a file which does not exist has none
+func readEnvLines(filePath string) ([]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(content) == 0 {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n"), nil
+}
+
+// envValues returns the values set by the lines
+func envValues(lines []string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range lines {
+		if key, value, isVariable := utils.ParseEnvLine(line); isVariable {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// envGroups groups the variables by the config section declaring them, in the order of the sections.
+// Variables read elsewhere, i.e by os.Getenv in handwritten code, are grouped last.
+func envGroups(usages []EnvUsage) []envGroup {
+	var groups []envGroup
+	grouped := lo.SliceToMap(config_utils.LoaderVariables, func(variable string) (string, bool) {
+		return variable, true
+	})
+
+	for _, section := range config_utils.ConfigSections {
+		if !config_utils.SectionImplemented(section.Name) {
+			continue
+		}
+
+		group := envGroup{Comment: fmt.Sprintf("%s %s", section.Name, section.Comment)}
+		for _, field := range section.Fields {
+			comments := lo.Compact([]string{field.Comment, lo.Ternary(field.Required, "required", "")})
+			group.Fields = append(group.Fields, envField{
+				Key:     field.Env,
+				Value:   field.Default,
+				Comment: strings.Join(comments, ", "),
+			})
+			grouped[field.Env] = true
+		}
+		groups = append(groups, group)
+	}
+
+	otherGroup := envGroup{Comment: "Read by the project"}
+	for _, usage := range usages {
+		if usage.Dynamic || grouped[usage.Key] {
+			continue
+		}
+
+		comments := lo.Compact([]string{fmt.Sprintf("read in %s", usage.Positions[0]), lo.Ternary(usage.Required, "required", "")})
+		otherGroup.Fields = append(otherGroup.Fields, envField{
+			Key:     usage.Key,
+			Value:   usage.Default,
+			Comment: strings.Join(comments, ", "),
+		})
+	}
+	if len(otherGroup.Fields) > 0 {
+		groups = append(groups, otherGroup)
+	}
+
+	return groups
+}
+
+// GenerateEnvExample writes .env.example with every variable the project reads, grouped and commented by component.
+// Defaults are filled in and secrets are left empty, so the file can be committed.
+func GenerateEnvExample() error {
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	usages, err := ScanEnvUsages(workingDirectory)
+	if err != nil {
+		return err
+	}
+
+	lines := []string{
+		"# Variables read by the project, generated by goblin env example.",
+		fmt.Sprintf("# Copy it to .env, or to .env.<profile> which is loaded on top of .env when %s=<profile>.", config_utils.ProfileVariable),
+	}
+
+	for _, group := range envGroups(usages) {
+		lines = append(lines, "", fmt.Sprintf("# %s", group.Comment))
+		for _, field := range group.Fields {
+			lines = append(lines, field.lines()...)
+		}
+	}
+
+	dynamicUsages := lo.Filter(usages, func(usage EnvUsage, _ int) bool {
+		return usage.Dynamic
+	})
+	if len(dynamicUsages) > 0 {
+		lines = append(lines, "", "# Read by names built at runtime, * is replaced by i.e the name of a route group")
+		for _, usage := range dynamicUsages {
+			lines = append(lines, fmt.Sprintf("# %s, read in %s", usage.Key, usage.Positions[0]))
+		}
+	}
+
+	return os.WriteFile(path.Join(workingDirectory, EnvExampleFileName), []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+func (f envField) lines() []string {
+	if f.Comment == "" {
+		return []string{fmt.Sprintf("%s=%s", f.Key, f.Value)}
+	}
+	return []string{fmt.Sprintf("# %s", f.Comment), fmt.Sprintf("%s=%s", f.Key, f.Value)}
+}
+
+// SyncEnvFile adds the variables the project reads to the env file of the profile and returns their keys.
+// Lines already in the file keep their values, comments and order, new keys are added after the other keys of their component.
+// Profiles other than prod start from the values of .env, prod only gets the defaults so development secrets do not leak into it.
+func SyncEnvFile(profile string) ([]string, error) {
+	err := ValidateProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := ScanEnvUsages(workingDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	envFilePath := path.Join(workingDirectory, EnvFilePath(profile))
+	envFileExists := utils.FileExists(envFilePath)
+
+	lines, err := readEnvLines(envFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !envFileExists && profile != "" {
+		lines = []string{fmt.Sprintf("# %s profile, loaded on top of .env when %s=%s", profile, config_utils.ProfileVariable, profile)}
+	}
+
+	seedValues := make(map[string]string)
+	if profile != "" && profile != ProfileProd {
+		baseLines, err := readEnvLines(path.Join(workingDirectory, EnvFileName))
+		if err != nil {
+			return nil, err
+		}
+		seedValues = envValues(baseLines)
+	}
+
+	var addedKeys []string
+	for _, group := range envGroups(usages) {
+		existingValues := envValues(lines)
+
+		var missingLines []string
+		for _, field := range group.Fields {
+			if _, exists := existingValues[field.Key]; exists {
+				continue
+			}
+			if seedValue, exists := seedValues[field.Key]; exists {
+				field.Value = seedValue
+			}
+			missingLines = append(missingLines, field.lines()...)
+			addedKeys = append(addedKeys, field.Key)
+		}
+
+		if len(missingLines) == 0 {
+			continue
+		}
+
+		// keys of a component already in the file are followed by the missing ones, otherwise the whole group is appended
+		_, lastGroupLine, found := lo.FindLastIndexOf(lines, func(line string) bool {
+			key, _, isVariable := utils.ParseEnvLine(line)
+			return isVariable && lo.ContainsBy(group.Fields, func(field envField) bool {
+				return field.Key == key
+			})
+		})
+		if found {
+			lines = append(lines[:lastGroupLine+1], append(missingLines, lines[lastGroupLine+1:]...)...)
+			continue
+		}
+
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, fmt.Sprintf("# %s", group.Comment))
+		lines = append(lines, missingLines...)
+	}
+
+	if len(addedKeys) == 0 && envFileExists {
+		return nil, nil
+	}
+
+	err = os.WriteFile(envFilePath, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	// profiles hold secrets just like .env
+	if profile != "" {
+		err = utils.AddToGitignore(EnvFilePath(profile))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return addedKeys, nil
+}
+
+// CheckEnvFile compares the variables the project reads with the ones set in the env file of the profile
+func CheckEnvFile(profile string) (*EnvReport, error) {
+	err := ValidateProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	envFilePath := path.Join(workingDirectory, EnvFilePath(profile))
+	encryptedEnvFilePath := path.Join(workingDirectory, EncryptedEnvFilePath(profile))
+	if !utils.FileExists(envFilePath) && !utils.FileExists(encryptedEnvFilePath) {
+		return nil, fmt.Errorf("%s does not exist, run goblin env%s to create it", EnvFilePath(profile), lo.Ternary(profile != "", " --profile "+profile, ""))
+	}
+
+	usages, err := ScanEnvUsages(workingDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := readEnvLines(envFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys of the encrypted file are readable, so it is checked together with the plain one without decrypting it
+	encryptedLines, err := readEnvLines(encryptedEnvFilePath)
+	if err != nil {
+		return nil, err
+	}
+	lines = append(lines, encryptedLines...)
+	values := envValues(lines)
+
+	report := &EnvReport{EnvFileName: lo.Ternary(utils.FileExists(envFilePath), EnvFilePath(profile), EncryptedEnvFilePath(profile))}
+	if utils.FileExists(envFilePath) && utils.FileExists(encryptedEnvFilePath) {
+		report.EnvFileName = fmt.Sprintf("%s and %s", EnvFilePath(profile), EncryptedEnvFilePath(profile))
+	}
+	readKeys := lo.SliceToMap(config_utils.LoaderVariables, func(variable string) (string, bool) {
+		return variable, true
+	})
+
+	for _, usage := range usages {
+		if usage.Dynamic {
+			report.Dynamic = append(report.Dynamic, usage)
+			continue
+		}
+
+		if readKeys[usage.Key] {
+			continue
+		}
+		readKeys[usage.Key] = true
+
+		value, isSet := values[usage.Key]
+		if (!isSet && usage.Default == "") || (usage.Required && strings.TrimSpace(value) == "") {
+			report.Missing = append(report.Missing, usage)
+		}
+	}
+
+	for _, line := range lines {
+		key, _, isVariable := utils.ParseEnvLine(line)
+		if !isVariable || readKeys[key] {
+			continue
+		}
+
+		readDynamically := lo.ContainsBy(report.Dynamic, func(usage EnvUsage) bool {
+			matches, err := path.Match(usage.Key, key)
+			return err == nil && matches
+		})
+		if !readDynamically && !lo.Contains(report.Unused, key) {
+			report.Unused = append(report.Unused, key)
+		}
+	}
+
+	return report, nil
+}
+
+// EncryptedEnvFilePath returns the encrypted counterpart of the env file of the profile
+func EncryptedEnvFilePath(profile string) string {
+	return EnvFilePath(profile) + config_utils.EncryptedFileSuffix
+}
+
+// readEncryptionKey reads the key from ENV_ENCRYPTION_KEY or the key file, the way the generated config does.
+// A new key is written to .env.key when there is none and create is set.
+func readEncryptionKey(create bool) (*[32]byte, error) {
+	encodedKey := strings.TrimSpace(os.Getenv(config_utils.EncryptionKeyVariable))
+	if encodedKey == "" {
+		keyFile := lo.CoalesceOrEmpty(os.Getenv(config_utils.EncryptionKeyFileVariable), config_utils.EncryptionKeyFileName)
+
+		content, err := os.ReadFile(keyFile)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		if errors.Is(err, fs.ErrNotExist) {
+			if !create {
+				return nil, fmt.Errorf("encryption key not found, set %s or %s, or put the key into %s", config_utils.EncryptionKeyVariable, config_utils.EncryptionKeyFileVariable, config_utils.EncryptionKeyFileName)
+			}
+			return generateEncryptionKey(keyFile)
+		}
+		encodedKey = strings.TrimSpace(string(content))
+	}
+
+	decodedKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(decodedKey) != 32 {
+		return nil, errors.New("encryption key has to be 32 base64 encoded bytes")
+	}
+
+	var key [32]byte
+	copy(key[:], decodedKey)
+	return &key, nil
+}
+
+// generateEncryptionKey writes a random key readable only by the owner, the key file is never committed
+func generateEncryptionKey(keyFile string) (*[32]byte, error) {
+	var key [32]byte
+	_, err := rand.Read(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key[:])+"\n"), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	err = utils.AddToGitignore(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func encryptValue(value string, key *[32]byte) (string, error) {
+	var nonce [nonceSize]byte
+	_, err := rand.Read(nonce[:])
+	if err != nil {
+		return "", err
+	}
+
+	sealed := secretbox.Seal(nonce[:], []byte(value), &nonce, key)
+	return config_utils.EncryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptValue(encryptedValue string, key *[32]byte) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encryptedValue, config_utils.EncryptedValuePrefix))
+	if err != nil || len(sealed) < nonceSize {
+		return "", errors.New("malformed encrypted value")
+	}
+
+	var nonce [nonceSize]byte
+	copy(nonce[:], sealed[:nonceSize])
+
+	decrypted, ok := secretbox.Open(nil, sealed[nonceSize:], &nonce, key)
+	if !ok {
+		return "", errors.New("the encryption key does not match")
+	}
+
+	return string(decrypted), nil
+}
+
+// encryptLines encrypts the values of the lines, comments and empty values are kept as they are.
+// Values which did not change keep their ciphertext from the previous lines, so diffs of .env.enc only show changed values.
+func encryptLines(lines []string, key *[32]byte, previousLines []string) ([]string, error) {
+	previousValues := envValues(previousLines)
+
+	encryptedLines := make([]string, len(lines))
+	for i, line := range lines {
+		name, _, isVariable := utils.ParseEnvLine(line)
+		_, value, _ := strings.Cut(line, "=")
+		value = strings.TrimSpace(value)
+		if !isVariable || value == "" {
+			encryptedLines[i] = line
+			continue
+		}
+
+		prefix := strings.TrimSpace(strings.SplitN(line, "=", 2)[0])
+		if previousValue, exists := previousValues[name]; exists && strings.HasPrefix(previousValue, config_utils.EncryptedValuePrefix) {
+			decryptedValue, err := decryptValue(previousValue, key)
+			if err == nil && decryptedValue == value {
+				encryptedLines[i] = prefix + "=" + previousValue
+				continue
+			}
+		}
+
+		encryptedValue, err := encryptValue(value, key)
+		if err != nil {
+			return nil, err
+		}
+		encryptedLines[i] = prefix + "=" + encryptedValue
+	}
+
+	return encryptedLines, nil
+}
+
+func decryptLines(lines []string, key *[32]byte) ([]string, error) {
+	decryptedLines := make([]string, len(lines))
+	for i, line := range lines {
+		name, value, isVariable := utils.ParseEnvLine(line)
+		if !isVariable || !strings.HasPrefix(strings.TrimSpace(value), config_utils.EncryptedValuePrefix) {
+			decryptedLines[i] = line
+			continue
+		}
+
+		decryptedValue, err := decryptValue(strings.TrimSpace(value), key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %w", name, err)
+		}
+		decryptedLines[i] = strings.TrimSpace(strings.SplitN(line, "=", 2)[0]) + "=" + decryptedValue
+	}
+
+	return decryptedLines, nil
+}
+
+// EncryptEnvFile encrypts the env file of the profile into its .enc counterpart, which can be committed.
+// The key is generated into .env.key on first use, the key file and the plain env file are added to .gitignore.
+func EncryptEnvFile(profile string) error {
+	err := ValidateProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	if !utils.FileExists(EnvFilePath(profile)) {
+		return fmt.Errorf("%s does not exist, run goblin env%s to create it", EnvFilePath(profile), lo.Ternary(profile != "", " --profile "+profile, ""))
+	}
+
+	key, err := readEncryptionKey(true)
+	if err != nil {
+		return err
+	}
+
+	lines, err := readEnvLines(EnvFilePath(profile))
+	if err != nil {
+		return err
+	}
+
+	previousLines, err := readEnvLines(EncryptedEnvFilePath(profile))
+	if err != nil {
+		return err
+	}
+
+	encryptedLines, err := encryptLines(lines, key, previousLines)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(EncryptedEnvFilePath(profile), []byte(strings.Join(encryptedLines, "\n")+"\n"), 0644)
+	if err != nil {
+		return err
+	}
+
+	return utils.AddToGitignore(EnvFilePath(profile))
+}
+
+// DecryptEnvFile writes the decrypted .enc file of the profile into its plain env file
+func DecryptEnvFile(profile string) error {
+	err := ValidateProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	if !utils.FileExists(EncryptedEnvFilePath(profile)) {
+		return fmt.Errorf("%s does not exist, run goblin env encrypt%s first", EncryptedEnvFilePath(profile), lo.Ternary(profile != "", " --profile "+profile, ""))
+	}
+
+	key, err := readEncryptionKey(false)
+	if err != nil {
+		return err
+	}
+
+	encryptedLines, err := readEnvLines(EncryptedEnvFilePath(profile))
+	if err != nil {
+		return err
+	}
+
+	lines, err := decryptLines(encryptedLines, key)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(EnvFilePath(profile), []byte(strings.Join(lines, "\n")+"\n"), 0600)
+	if err != nil {
+		return err
+	}
+
+	return utils.AddToGitignore(EnvFilePath(profile))
+}
+
+// EditEnvFile opens the decrypted .enc file of the profile in $VISUAL or $EDITOR and encrypts it again once the editor exits.
+// The decrypted values are only written to a temporary file readable by the owner, which is removed afterwards.
+func EditEnvFile(profile string) error {
+	err := ValidateProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	key, err := readEncryptionKey(true)
+	if err != nil {
+		return err
+	}
+
+	encryptedLines, err := readEnvLines(EncryptedEnvFilePath(profile))
+	if err != nil {
+		return err
+	}
+
+	// the first edit starts from the plain env file
+	if !utils.FileExists(EncryptedEnvFilePath(profile)) {
+		encryptedLines, err = readEnvLines(EnvFilePath(profile))
+		if err != nil {
+			return err
+		}
+	}
+
+	lines, err := decryptLines(encryptedLines, key)
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp("", "goblin-env-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.WriteString(strings.Join(lines, "\n") + "\n")
+	if err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	err = tempFile.Close()
+	if err != nil {
+		return err
+	}
+
+	editor := strings.Fields(lo.CoalesceOrEmpty(os.Getenv("VISUAL"), os.Getenv("EDITOR"), "vi"))
+	editorCmd := exec.Command(editor[0], append(editor[1:], tempFile.Name())...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	err = editorCmd.Run()
+	if err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+
+	editedLines, err := readEnvLines(tempFile.Name())
+	if err != nil {
+		return err
+	}
+
+	editedEncryptedLines, err := encryptLines(editedLines, key, encryptedLines)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(EncryptedEnvFilePath(profile), []byte(strings.Join(editedEncryptedLines, "\n")+"\n"), 0644)
+}
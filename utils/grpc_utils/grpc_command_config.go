@@ -0,0 +1,24 @@
+package grpc_utils
+
+const (
+	GrpcServerTemplatePath = "grpc_server.tmpl"
+	GrpcServerTemplateName = "grpc_server.tmpl"
+
+	GrpcServiceServerTemplatePath = "grpc_service_server.tmpl"
+	GrpcServiceServerTemplateName = "grpc_service_server.tmpl"
+
+	GrpcCodecTemplatePath = "grpc_codec.tmpl"
+	GrpcCodecTemplateName = "grpc_codec.tmpl"
+
+	GrpcDocTemplatePath = "grpc_doc.tmpl"
+	GrpcDocTemplateName = "grpc_doc.tmpl"
+
+	GrpcServiceProtoTemplatePath = "grpc_service_proto.tmpl"
+	GrpcServiceProtoTemplateName = "grpc_service_proto.tmpl"
+
+	GrpcModelsProtoTemplatePath = "grpc_models_proto.tmpl"
+	GrpcModelsProtoTemplateName = "grpc_models_proto.tmpl"
+
+	// ProtoFolderName is the subfolder of the grpc folder holding the .proto files describing the JSON messages
+	ProtoFolderName = "proto"
+)
@@ -0,0 +1,579 @@
+package grpc_utils
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/templates"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/apperrors_utils"
+	"github.com/davidh16/goblin/utils/service_utils"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+type GrpcFieldData struct {
+	Name      string // i.e Car
+	JsonName  string // i.e car
+	GoType    string // i.e *models.Car
+	ProtoType string // i.e Car
+	Number    int    // proto field number
+}
+
+type GrpcMethodData struct {
+	Name          string // i.e CreateCar
+	Params        []GrpcFieldData
+	Results       []GrpcFieldData
+	PassesContext bool   // the service method expects a context.Context as its first parameter
+	ReturnsError  bool   // the service method returns an error as its last result
+	CallLhs       string // i.e result, err :=
+	CallArgs      string // i.e ctx, req.Car
+	ResponseBody  string // i.e Result: result
+}
+
+type GrpcServiceData struct {
+	ServiceEntity        string // i.e Car
+	ServiceFullName      string // i.e CarService
+	ServiceNameSnakeCase string // i.e car
+	ServiceNameCamelCase string // i.e car
+	Methods              []GrpcMethodData
+	SkippedMethods       []string // methods whose signature can not be described in a .proto file
+	Imports              []string // imports needed by the request and response types
+	UsesModels           bool     // at least one message references a model message
+}
+
+type GrpcMessageData struct {
+	Name   string
+	Fields []GrpcFieldData
+}
+
+// grpcPackage returns the go package name of the grpc folder, it is also used as the proto package name
+func grpcPackage() string {
+	return strings.Split(cli_config.CliConfig.GrpcFolderPath, "/")[len(strings.Split(cli_config.CliConfig.GrpcFolderPath, "/"))-1]
+}
+
+func modelsPackage() string {
+	return strings.Split(cli_config.CliConfig.ModelsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ModelsFolderPath, "/"))-1]
+}
+
+func servicesPackage() string {
+	return strings.Split(cli_config.CliConfig.ServicesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ServicesFolderPath, "/"))-1]
+}
+
+// ParseServiceInterface reads the <Entity>ServiceInterface of the provided service and describes
+// every method in terms of request and response messages.
+//
+// Methods with parameters or results that can not be represented in a .proto file
+// (channels, functions, interfaces, types from unknown packages...) are listed in SkippedMethods.
+func ParseServiceInterface(serviceData service_utils.ServiceData) (*GrpcServiceData, error) {
+	fileSet := token.NewFileSet()
+	node, err := parser.ParseFile(fileSet, serviceData.ServiceFilePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServiceData := &GrpcServiceData{
+		ServiceEntity:        serviceData.ServiceEntity,
+		ServiceFullName:      serviceData.ServiceFullName,
+		ServiceNameSnakeCase: serviceData.ServiceNameSnakeCase,
+		ServiceNameCamelCase: utils.PascalToCamel(serviceData.ServiceEntity),
+	}
+
+	// package name -> import spec, used to carry over the imports the request and response types depend on
+	fileImports := map[string]string{}
+	for _, imp := range node.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+			fileImports[name] = fmt.Sprintf("%s %q", name, importPath)
+			continue
+		}
+		fileImports[name] = strconv.Quote(importPath)
+	}
+
+	var interfaceType *ast.InterfaceType
+	ast.Inspect(node, func(n ast.Node) bool {
+		typeSpec, ok := n.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != serviceData.ServiceFullName+"Interface" {
+			return true
+		}
+		interfaceType, _ = typeSpec.Type.(*ast.InterfaceType)
+		return false
+	})
+
+	if interfaceType == nil {
+		return nil, fmt.Errorf("%sInterface not found in %s", serviceData.ServiceFullName, serviceData.ServiceFilePath)
+	}
+
+	usedPackages := map[string]bool{}
+
+	for _, field := range interfaceType.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			continue // embedded interfaces are not supported
+		}
+
+		methodData, packages, supported := parseMethod(field.Names[0].Name, funcType)
+		if !supported {
+			grpcServiceData.SkippedMethods = append(grpcServiceData.SkippedMethods, field.Names[0].Name)
+			continue
+		}
+
+		for _, pkg := range packages {
+			usedPackages[pkg] = true
+		}
+
+		grpcServiceData.Methods = append(grpcServiceData.Methods, *methodData)
+	}
+
+	for pkg := range usedPackages {
+		if pkg == modelsPackage() {
+			grpcServiceData.UsesModels = true
+		}
+		imp, ok := fileImports[pkg]
+		if !ok {
+			return nil, fmt.Errorf("unable to resolve import of package %s used by %s", pkg, serviceData.ServiceFullName)
+		}
+		grpcServiceData.Imports = append(grpcServiceData.Imports, imp)
+	}
+	sort.Strings(grpcServiceData.Imports)
+
+	return grpcServiceData, nil
+}
+
+// parseMethod converts a single interface method into GrpcMethodData.
+// It returns the packages referenced by the request and response types and whether the method is supported.
+func parseMethod(methodName string, funcType *ast.FuncType) (*GrpcMethodData, []string, bool) {
+	methodData := &GrpcMethodData{Name: methodName}
+	var packages []string
+	var callArgs []string
+
+	if funcType.Params != nil {
+		for i, param := range funcType.Params.List {
+			if i == 0 && types.ExprString(param.Type) == "context.Context" {
+				methodData.PassesContext = true
+				callArgs = append(callArgs, "ctx")
+				continue
+			}
+
+			if _, variadic := param.Type.(*ast.Ellipsis); variadic {
+				return nil, nil, false
+			}
+
+			protoType, ok := protoTypeOf(param.Type, false)
+			if !ok {
+				return nil, nil, false
+			}
+			packages = append(packages, referencedPackages(param.Type)...)
+
+			names := param.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", len(methodData.Params)+1))}
+			}
+
+			for _, name := range names {
+				fieldName := strings.ToUpper(name.Name[:1]) + name.Name[1:]
+				methodData.Params = append(methodData.Params, GrpcFieldData{
+					Name:      fieldName,
+					JsonName:  utils.PascalToSnake(fieldName),
+					GoType:    types.ExprString(param.Type),
+					ProtoType: protoType,
+					Number:    len(methodData.Params) + 1,
+				})
+				callArgs = append(callArgs, "req."+fieldName)
+			}
+		}
+	}
+
+	var resultTypes []ast.Expr
+	if funcType.Results != nil {
+		for _, result := range funcType.Results.List {
+			count := len(result.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				resultTypes = append(resultTypes, result.Type)
+			}
+		}
+	}
+
+	if len(resultTypes) > 0 {
+		if ident, ok := resultTypes[len(resultTypes)-1].(*ast.Ident); ok && ident.Name == "error" {
+			methodData.ReturnsError = true
+			resultTypes = resultTypes[:len(resultTypes)-1]
+		}
+	}
+
+	var lhs []string
+	var responseBody []string
+	for i, resultType := range resultTypes {
+		protoType, ok := protoTypeOf(resultType, false)
+		if !ok {
+			return nil, nil, false
+		}
+		packages = append(packages, referencedPackages(resultType)...)
+
+		fieldName := "Result"
+		if len(resultTypes) > 1 {
+			fieldName = fmt.Sprintf("Result%d", i+1)
+		}
+		variableName := utils.PascalToCamel(fieldName)
+
+		methodData.Results = append(methodData.Results, GrpcFieldData{
+			Name:      fieldName,
+			JsonName:  utils.PascalToSnake(fieldName),
+			GoType:    types.ExprString(resultType),
+			ProtoType: protoType,
+			Number:    i + 1,
+		})
+		lhs = append(lhs, variableName)
+		responseBody = append(responseBody, fmt.Sprintf("%s: %s", fieldName, variableName))
+	}
+
+	if methodData.ReturnsError {
+		lhs = append(lhs, "err")
+	}
+	if len(lhs) > 0 {
+		methodData.CallLhs = strings.Join(lhs, ", ") + " :="
+	}
+	methodData.CallArgs = strings.Join(callArgs, ", ")
+	methodData.ResponseBody = strings.Join(responseBody, ", ")
+
+	return methodData, packages, true
+}
+
+// protoTypeOf maps a go type expression to its proto3 counterpart.
+// Model types are mapped to messages declared in models.proto, time.Time is carried as an RFC 3339 string.
+// When insideModels is true, bare identifiers are treated as messages of the models package.
+func protoTypeOf(expr ast.Expr, insideModels bool) (string, bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return protoTypeOf(t.X, insideModels)
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return "string", true
+		case "bool":
+			return "bool", true
+		case "int", "int64":
+			return "int64", true
+		case "int8", "int16", "int32":
+			return "int32", true
+		case "uint", "uint64":
+			return "uint64", true
+		case "uint8", "uint16", "uint32":
+			return "uint32", true
+		case "float64":
+			return "double", true
+		case "float32":
+			return "float", true
+		}
+		if insideModels && ast.IsExported(t.Name) {
+			return t.Name, true
+		}
+		return "", false
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		switch {
+		case pkg.Name == modelsPackage():
+			return t.Sel.Name, true
+		case pkg.Name == "time" && t.Sel.Name == "Time":
+			return "string", true
+		case pkg.Name == "uuid" && t.Sel.Name == "UUID":
+			return "string", true
+		}
+		return "", false
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return "bytes", true
+		}
+		elementType, ok := protoTypeOf(t.Elt, insideModels)
+		if !ok || strings.HasPrefix(elementType, "repeated ") || strings.HasPrefix(elementType, "map<") {
+			return "", false
+		}
+		return "repeated " + elementType, true
+	case *ast.MapType:
+		keyType, ok := protoTypeOf(t.Key, insideModels)
+		if !ok || keyType == "double" || keyType == "float" || keyType == "bytes" {
+			return "", false
+		}
+		valueType, ok := protoTypeOf(t.Value, insideModels)
+		if !ok || strings.HasPrefix(valueType, "repeated ") || strings.HasPrefix(valueType, "map<") {
+			return "", false
+		}
+		return fmt.Sprintf("map<%s, %s>", keyType, valueType), true
+	}
+	return "", false
+}
+
+// referencedPackages returns the package names referenced by selector expressions within the provided type
+func referencedPackages(expr ast.Expr) []string {
+	var packages []string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if selector, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				packages = append(packages, ident.Name)
+			}
+		}
+		return true
+	})
+	return packages
+}
+
+// ListModelMessages scans the models folder and describes every exported struct as a proto message.
+// Field names are the ones encoding/json uses, fields which can not be represented in proto3 are left out.
+func ListModelMessages() ([]GrpcMessageData, error) {
+	var messages []GrpcMessageData
+
+	err := filepath.WalkDir(cli_config.CliConfig.ModelsFolderPath, func(modelPath string, d fs.DirEntry, err error) error {
+		if err != nil || !strings.HasSuffix(modelPath, ".go") {
+			return nil // skip non-Go files
+		}
+
+		fileSet := token.NewFileSet()
+		node, err := parser.ParseFile(fileSet, modelPath, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+
+		for _, decl := range node.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || !typeSpec.Name.IsExported() {
+					continue
+				}
+
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				message := GrpcMessageData{Name: typeSpec.Name.Name}
+				for _, field := range structType.Fields.List {
+					if len(field.Names) == 0 || !field.Names[0].IsExported() {
+						continue
+					}
+
+					protoType, ok := protoTypeOf(field.Type, true)
+					if !ok {
+						continue
+					}
+
+					// encoding/json uses the field name of fields without a json tag
+					jsonName := field.Names[0].Name
+					if field.Tag != nil {
+						tag, _ := strconv.Unquote(field.Tag.Value)
+						jsonTag := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+						if jsonTag == "-" {
+							continue
+						}
+						if jsonTag != "" {
+							jsonName = jsonTag
+						}
+					}
+
+					message.Fields = append(message.Fields, GrpcFieldData{
+						Name:      field.Names[0].Name,
+						JsonName:  jsonName,
+						GoType:    types.ExprString(field.Type),
+						ProtoType: protoType,
+						Number:    len(message.Fields) + 1,
+					})
+				}
+				messages = append(messages, message)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Name < messages[j].Name
+	})
+
+	return messages, nil
+}
+
+// ListImplementedGrpcServices returns the services which already have a gRPC service server generated
+func ListImplementedGrpcServices() ([]service_utils.ServiceData, error) {
+	existingServices, err := service_utils.ListExistingServices()
+	if err != nil {
+		return nil, err
+	}
+
+	var implemented []service_utils.ServiceData
+	for _, service := range existingServices {
+		if utils.FileExists(path.Join(cli_config.CliConfig.GrpcFolderPath, service.ServiceNameSnakeCase+"_server.go")) {
+			implemented = append(implemented, service)
+		}
+	}
+
+	return implemented, nil
+}
+
+// GenerateServiceServer generates <service>_server.go containing the request/response types,
+// the service server delegating to the central service, its service descriptor and a client.
+func GenerateServiceServer(grpcServiceData *GrpcServiceData) error {
+	templateData := struct {
+		GrpcPackage           string
+		ServicesPackage       string
+		ServicesPackageImport string
+		*GrpcServiceData
+	}{
+		GrpcPackage:           grpcPackage(),
+		ServicesPackage:       servicesPackage(),
+		ServicesPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ServicesFolderPath),
+		GrpcServiceData:       grpcServiceData,
+	}
+
+	return executeGoTemplate(GrpcServiceServerTemplateName, GrpcServiceServerTemplatePath, path.Join(cli_config.CliConfig.GrpcFolderPath, grpcServiceData.ServiceNameSnakeCase+"_server.go"), templateData)
+}
+
+// GenerateServiceProto generates the .proto file describing the JSON messages of the provided service
+func GenerateServiceProto(grpcServiceData *GrpcServiceData) error {
+	templateData := struct {
+		ProtoPackage string
+		GoPackage    string
+		*GrpcServiceData
+	}{
+		ProtoPackage:    grpcPackage(),
+		GoPackage:       path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.GrpcFolderPath, "pb") + ";pb",
+		GrpcServiceData: grpcServiceData,
+	}
+
+	return executeTemplate(GrpcServiceProtoTemplateName, GrpcServiceProtoTemplatePath, path.Join(cli_config.CliConfig.GrpcFolderPath, ProtoFolderName, grpcServiceData.ServiceNameSnakeCase+".proto"), templateData)
+}
+
+// GenerateModelsProto generates models.proto declaring a message for every model
+func GenerateModelsProto() error {
+	messages, err := ListModelMessages()
+	if err != nil {
+		return err
+	}
+
+	templateData := struct {
+		ProtoPackage string
+		GoPackage    string
+		Messages     []GrpcMessageData
+	}{
+		ProtoPackage: grpcPackage(),
+		GoPackage:    path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.GrpcFolderPath, "pb") + ";pb",
+		Messages:     messages,
+	}
+
+	return executeTemplate(GrpcModelsProtoTemplateName, GrpcModelsProtoTemplatePath, path.Join(cli_config.CliConfig.GrpcFolderPath, ProtoFolderName, "models.proto"), templateData)
+}
+
+// GenerateServer generates server.go, registering every implemented service server, together with
+// the json codec the servers use and the package documentation.
+func GenerateServer() error {
+	implementedServices, err := ListImplementedGrpcServices()
+	if err != nil {
+		return err
+	}
+
+	templateData := struct {
+		GrpcPackage            string
+		ServicesPackage        string
+		ServicesPackageImport  string
+		Services               []service_utils.ServiceData
+		AppErrorsImplemented   bool
+		AppErrorsPackage       string
+		AppErrorsPackageImport string
+	}{
+		GrpcPackage:            grpcPackage(),
+		ServicesPackage:        servicesPackage(),
+		ServicesPackageImport:  path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ServicesFolderPath),
+		Services:               implementedServices,
+		AppErrorsImplemented:   utils.FileExists(path.Join(cli_config.CliConfig.AppErrorsFolderPath, apperrors_utils.AppErrorsFileName)),
+		AppErrorsPackage:       apperrors_utils.AppErrorsPackage(),
+		AppErrorsPackageImport: apperrors_utils.AppErrorsPackageImport(),
+	}
+
+	err = executeGoTemplate(GrpcServerTemplateName, GrpcServerTemplatePath, path.Join(cli_config.CliConfig.GrpcFolderPath, "server.go"), templateData)
+	if err != nil {
+		return err
+	}
+
+	err = executeGoTemplate(GrpcCodecTemplateName, GrpcCodecTemplatePath, path.Join(cli_config.CliConfig.GrpcFolderPath, "codec.go"), templateData)
+	if err != nil {
+		return err
+	}
+
+	return executeGoTemplate(GrpcDocTemplateName, GrpcDocTemplatePath, path.Join(cli_config.CliConfig.GrpcFolderPath, "doc.go"), templateData)
+}
+
+// executeTemplate renders the template into the destination file, creating parent folders if needed
+func executeTemplate(templateName, templatePath, destination string, templateData any) error {
+	content, err := renderTemplate(templateName, templatePath, templateData)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(destination, content)
+}
+
+// executeGoTemplate renders the template into the destination file and formats the result with gofmt
+func executeGoTemplate(templateName, templatePath, destination string, templateData any) error {
+	content, err := renderTemplate(templateName, templatePath, templateData)
+	if err != nil {
+		return err
+	}
+
+	formatted, err := format.Source(content)
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", destination, err)
+	}
+
+	return writeFile(destination, formatted)
+}
+
+func renderTemplate(templateName, templatePath string, templateData any) ([]byte, error) {
+	tmpl, err := template.New(templateName).ParseFS(templates.Files, templatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, templateData)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeFile(destination string, content []byte) error {
+	err := os.MkdirAll(filepath.Dir(destination), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(destination, content, 0644)
+}
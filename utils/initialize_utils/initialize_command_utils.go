@@ -8,9 +8,13 @@ import (
 	central_repo "github.com/davidh16/goblin/commands/repo/flags/central-repo"
 	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/app_utils"
+	"github.com/davidh16/goblin/utils/auth_utils"
+	"github.com/davidh16/goblin/utils/config_utils"
 	"github.com/davidh16/goblin/utils/controller_utils"
 	"github.com/davidh16/goblin/utils/database_utils"
 	"github.com/davidh16/goblin/utils/middleware_utils"
+	"github.com/davidh16/goblin/utils/observability_utils"
 	"github.com/davidh16/goblin/utils/repo_utils"
 	"github.com/davidh16/goblin/utils/router_utils"
 	"github.com/davidh16/goblin/utils/service_utils"
@@ -110,6 +114,12 @@ func ExecuteRouter(routerData *router_utils.RouterData, selectedMiddlewares []st
 			routerData.RateLimiterMiddleware = true
 		case "AllowOriginMiddleware":
 			routerData.AllowOriginMiddleware = true
+		case "IdempotencyMiddleware":
+			routerData.IdempotencyMiddleware = true
+		case "RequestIDMiddleware":
+			routerData.RequestIDMiddleware = true
+		case "MetricsMiddleware":
+			routerData.MetricsMiddleware = true
 		}
 	}
 
@@ -260,3 +270,227 @@ func ExecuteCentralController(implementCentralService bool) error {
 	return nil
 
 }
+
+type MainData struct {
+	ImplementCentralRepository bool
+	ImplementCentralService    bool
+	LoggerImplemented          bool
+	GrpcImplemented            bool
+	RbacImplemented            bool
+	TracingImplemented         bool
+	MetricsImplemented         bool
+	PostgresImplemented        bool
+	MariaDBImplemented         bool
+	RedisImplemented           bool
+	WorkersImplemented         bool
+	SchedulerImplemented       bool
+	JobsAdminImplemented       bool
+}
+
+func NewMainData() *MainData {
+	return &MainData{}
+}
+
+// DetectMainData inspects the project and reports which of the components wired in main.go
+// have already been generated. It is used when main.go has to be regenerated outside
+// the initialize command, e.g. after the gRPC transport layer is added.
+func DetectMainData() *MainData {
+	return &MainData{
+		ImplementCentralRepository: utils.FileExists(path.Join(cli_config.CliConfig.RepositoriesFolderPath, "central_repo.go")),
+		ImplementCentralService:    utils.FileExists(path.Join(cli_config.CliConfig.ServicesFolderPath, "central_service.go")),
+		LoggerImplemented:          utils.FileExists(path.Join(cli_config.CliConfig.LoggerFolderPath, "logger.go")),
+		GrpcImplemented:            utils.FileExists(path.Join(cli_config.CliConfig.GrpcFolderPath, "server.go")),
+		RbacImplemented:            auth_utils.RbacImplemented(),
+		TracingImplemented:         observability_utils.TracingImplemented(),
+		MetricsImplemented:         observability_utils.MetricsImplemented(),
+		PostgresImplemented:        utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, database_utils.DatabaseOptionInstanceDefaultFileNamesMap[database_utils.PostgresSQL])),
+		MariaDBImplemented:         utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, database_utils.DatabaseOptionInstanceDefaultFileNamesMap[database_utils.MariaDB])),
+		RedisImplemented:           utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, database_utils.DatabaseOptionInstanceDefaultFileNamesMap[database_utils.Redis])),
+		WorkersImplemented:         utils.FileExists(path.Join(cli_config.CliConfig.WorkersFolderPath, "orchestrator.go")) && utils.FileExists(path.Join(cli_config.CliConfig.JobsFolderPath, "jobs_manager.go")),
+		SchedulerImplemented:       utils.FileExists(path.Join(cli_config.CliConfig.WorkersFolderPath, "scheduler.go")),
+		JobsAdminImplemented:       utils.FileExists(path.Join(cli_config.CliConfig.JobsFolderPath, "admin.go")),
+	}
+}
+
+// WorkersConnected reports whether main.go starts the orchestrator, the jobs manager needs a database to save failed jobs to.
+// main.go then takes a --role flag, so the API and the workers can run as separate processes.
+func (m *MainData) WorkersConnected() bool {
+	return m.WorkersImplemented && m.ImplementCentralService && (m.ImplementCentralRepository || m.PostgresImplemented || m.MariaDBImplemented)
+}
+
+// GenerateMainFile renders main.tmpl into main.go in the working directory using the provided main data.
+func GenerateMainFile(mainData *MainData) error {
+	// main.go registers a readiness check for every database it connects to
+	err := router_utils.GenerateHealthFile()
+	if err != nil {
+		return err
+	}
+
+	// databases, workers and servers are started and stopped by the lifecycle manager
+	err = app_utils.GenerateApp()
+	if err != nil {
+		return err
+	}
+
+	workersConnected := mainData.WorkersConnected()
+
+	// main.go loads the config and takes the addresses of the servers it starts, and of the jobs queue, from it
+	configSections := []string{config_utils.ServerSection}
+	if mainData.LoggerImplemented {
+		configSections = append(configSections, config_utils.LoggerSection)
+	}
+	if mainData.GrpcImplemented && mainData.ImplementCentralService {
+		configSections = append(configSections, config_utils.GrpcSection)
+	}
+	if mainData.MetricsImplemented {
+		configSections = append(configSections, config_utils.MetricsSection)
+	}
+	if workersConnected {
+		configSections = append(configSections, config_utils.RedisSection)
+	}
+
+	err = config_utils.GenerateConfig(configSections...)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.ParseFS(templates.Files, MainTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path.Join(workingDirectory, "main.go"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	templateData := struct {
+		RouterPackage       string
+		RouterPackageImport string
+
+		ControllersPackageImport string
+		ControllersPackage       string
+
+		ServicesPackageImport string
+		ServicesPackage       string
+
+		RepositoriesPackageImport string
+		RepositoriesPackage       string
+
+		DatabasesPackageImport string
+		DatabasesPackage       string
+
+		GrpcPackageImport string
+		GrpcPackage       string
+
+		AppPackageImport string
+		AppPackage       string
+
+		ConfigPackageImport string
+		ConfigPackage       string
+
+		ImplementCentralRepository bool
+		ImplementCentralService    bool
+		GrpcImplemented            bool
+
+		RbacImplemented   bool
+		AuthPackage       string
+		AuthPackageImport string
+
+		RedisImplemented       bool
+		RouterRequiresDatabase bool
+
+		// every database connected to is pinged by /readyz
+		PostgresConnected bool
+		MariaDBConnected  bool
+		RedisConnected    bool
+
+		// the orchestrator is started when the jobs manager has a database to save failed jobs to
+		WorkersConnected     bool
+		JobsDatabase         string
+		JobsPackageImport    string
+		JobsPackage          string
+		WorkersPackageImport string
+		WorkersPackage       string
+		// the scheduler enqueues the recurring jobs through the jobs manager of the workers
+		SchedulerImplemented bool
+		// the binary manages the failed jobs when run with the jobs admin command
+		JobsAdminImplemented bool
+
+		LoggerImplemented   bool
+		LoggerPackage       string
+		LoggerPackageImport string
+
+		TracingImplemented     bool
+		MetricsImplemented     bool
+		TelemetryPackage       string
+		TelemetryPackageImport string
+	}{
+		RouterPackage:       strings.Split(cli_config.CliConfig.RouterFolderPath, "/")[len(strings.Split(cli_config.CliConfig.RouterFolderPath, "/"))-1],
+		RouterPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.RouterFolderPath),
+
+		ControllersPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ControllersFolderPath),
+		ControllersPackage:       strings.Split(cli_config.CliConfig.ControllersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ControllersFolderPath, "/"))-1],
+
+		ServicesPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ServicesFolderPath),
+		ServicesPackage:       strings.Split(cli_config.CliConfig.ServicesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ServicesFolderPath, "/"))-1],
+
+		RepositoriesPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.RepositoriesFolderPath),
+		RepositoriesPackage:       strings.Split(cli_config.CliConfig.RepositoriesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.RepositoriesFolderPath, "/"))-1],
+
+		DatabasesPackage:       strings.Split(cli_config.CliConfig.DatabaseInstancesFolderPath, "/")[len(strings.Split(cli_config.CliConfig.DatabaseInstancesFolderPath, "/"))-1],
+		DatabasesPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.DatabaseInstancesFolderPath),
+
+		GrpcPackage:       strings.Split(cli_config.CliConfig.GrpcFolderPath, "/")[len(strings.Split(cli_config.CliConfig.GrpcFolderPath, "/"))-1],
+		GrpcPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.GrpcFolderPath),
+
+		AppPackage:       app_utils.AppPackage(),
+		AppPackageImport: app_utils.AppPackageImport(),
+
+		ConfigPackage:       config_utils.ConfigPackage(),
+		ConfigPackageImport: config_utils.ConfigPackageImport(),
+
+		ImplementCentralRepository: mainData.ImplementCentralRepository,
+		ImplementCentralService:    mainData.ImplementCentralService,
+		GrpcImplemented:            mainData.GrpcImplemented && mainData.ImplementCentralService,
+
+		// the policy is loaded from the database, so it can only be wired when the central repository connects to one
+		RbacImplemented:   mainData.RbacImplemented && mainData.ImplementCentralRepository,
+		AuthPackage:       auth_utils.AuthPackage(),
+		AuthPackageImport: auth_utils.AuthPackageImport(),
+
+		// main.go has to pass the Redis client whenever the existing router expects it
+		RedisImplemented:       router_utils.RouterRequiresRedis(),
+		RouterRequiresDatabase: router_utils.RouterRequiresDatabase() && mainData.ImplementCentralRepository,
+
+		PostgresConnected: mainData.ImplementCentralRepository || mainData.PostgresImplemented,
+		MariaDBConnected:  mainData.MariaDBImplemented,
+		RedisConnected:    router_utils.RouterRequiresRedis() || mainData.RedisImplemented,
+
+		WorkersConnected:     workersConnected,
+		JobsDatabase:         lo.Ternary(mainData.ImplementCentralRepository || mainData.PostgresImplemented, "db", "mariaDb"),
+		JobsPackageImport:    path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.JobsFolderPath),
+		JobsPackage:          strings.Split(cli_config.CliConfig.JobsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.JobsFolderPath, "/"))-1],
+		WorkersPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.WorkersFolderPath),
+		WorkersPackage:       strings.Split(cli_config.CliConfig.WorkersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.WorkersFolderPath, "/"))-1],
+		SchedulerImplemented: workersConnected && mainData.SchedulerImplemented,
+		JobsAdminImplemented: workersConnected && mainData.JobsAdminImplemented,
+
+		LoggerImplemented:   mainData.LoggerImplemented,
+		LoggerPackage:       strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
+		LoggerPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.LoggerFolderPath),
+
+		TracingImplemented:     mainData.TracingImplemented,
+		MetricsImplemented:     mainData.MetricsImplemented,
+		TelemetryPackage:       observability_utils.TelemetryPackage(),
+		TelemetryPackageImport: observability_utils.TelemetryPackageImport(),
+	}
+
+	return tmpl.Execute(f, templateData)
+}
@@ -0,0 +1,9 @@
+package jobs_utils
+
+const (
+	// AdminCommand is the first argument the generated main.go runs the failed jobs admin with
+	AdminCommand = "jobs"
+	// AdminEntryPoint is called by main.go running the failed jobs admin, main.go generated before goblin jobs doesn't call it
+	AdminEntryPoint = "RunAdmin("
+	MainFilePath    = "main.go"
+)
@@ -0,0 +1,59 @@
+package jobs_utils
+
+import (
+	"errors"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/workerize_utils"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// FilterFlags select the failed jobs by type and by the time they failed at, zero values don't filter
+type FilterFlags struct {
+	JobType string
+	Since   time.Duration
+	Until   time.Duration
+}
+
+// Args returns the flags of the failed jobs admin which are set
+func (f FilterFlags) Args() []string {
+	var args []string
+	if f.JobType != "" {
+		args = append(args, "--type", f.JobType)
+	}
+	if f.Since > 0 {
+		args = append(args, "--since", f.Since.String())
+	}
+	if f.Until > 0 {
+		args = append(args, "--until", f.Until.String())
+	}
+	return args
+}
+
+// RunJobsAdmin runs the failed jobs admin generated in the project with go run, so it uses the jobs manager, the database and Redis of the project.
+// Deployed binaries are run with the same arguments, i.e <binary> jobs list.
+func RunJobsAdmin(args ...string) error {
+	if !utils.FileExists(MainFilePath) {
+		return errors.New("main.go does not exist, run goblin initialize first")
+	}
+
+	if !workerize_utils.JobsAdminImplemented() {
+		return errors.New("failed jobs admin is not implemented, run goblin workerize and overwrite jobs_manager.go first")
+	}
+
+	mainFile, err := os.ReadFile(MainFilePath)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(mainFile), AdminEntryPoint) {
+		return errors.New("main.go does not run the failed jobs admin, regenerate it with goblin workerize")
+	}
+
+	cmd := exec.Command("go", append([]string{"run", ".", AdminCommand}, args...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
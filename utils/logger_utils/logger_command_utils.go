@@ -4,6 +4,7 @@ import (
 	"github.com/davidh16/goblin/cli_config"
 	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/config_utils"
 	"os"
 	"path"
 	"strings"
@@ -36,14 +37,30 @@ func GenerateLogger() error {
 	defer f.Close()
 
 	templateData := struct {
-		LoggerPackage string
+		LoggerPackage       string
+		ConfigPackage       string
+		ConfigPackageImport string
 	}{
-		LoggerPackage: strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
+		LoggerPackage:       strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
+		ConfigPackage:       config_utils.ConfigPackage(),
+		ConfigPackageImport: config_utils.ConfigPackageImport(),
 	}
 
 	err = tmpl.Execute(f, templateData)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	// the level and the Graylog address are read from the config
+	return config_utils.GenerateConfig(config_utils.LoggerSection)
+}
+
+// EnsureLogger generates the logger unless it exists already with FromContext, loggers generated before it was added are regenerated
+func EnsureLogger() error {
+	loggerFile, err := os.ReadFile(path.Join(cli_config.CliConfig.LoggerFolderPath, LoggerFileName))
+	if err == nil && strings.Contains(string(loggerFile), "func (l *CustomLogger) FromContext(") {
+		return nil
+	}
+
+	return GenerateLogger()
 }
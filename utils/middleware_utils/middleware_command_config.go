@@ -5,6 +5,32 @@ const (
 	AllowOriginMiddlewareTemplatePath = "allow_origin_middleware.tmpl"
 	LoggingMiddlewareTemplatePath     = "logging_middleware.tmpl"
 	JwtMiddlewareTemplatePath         = "jwt_middleware.tmpl"
+	RbacMiddlewareTemplatePath        = "rbac_middleware.tmpl"
+	ApiKeyMiddlewareTemplatePath      = "api_key_middleware.tmpl"
+	IdempotencyMiddlewareTemplatePath = "idempotency_middleware.tmpl"
+	RequestIDMiddlewareTemplatePath   = "request_id_middleware.tmpl"
+	MetricsMiddlewareTemplatePath     = "metrics_middleware.tmpl"
 
-	AuthJwtTemplatePath = "auth_jwt.tmpl"
+	RateLimiterRedisStoreTemplatePath = "rate_limiter_redis_store.tmpl"
+	RateLimiterRedisStoreFileName     = "rate_limiter_redis_store.go"
+	RateLimitPolicyTemplatePath       = "rate_limit_policy.tmpl"
+	RateLimitPolicyFileName           = "rate_limit_policy.go"
+	RateLimitsConfigTemplatePath      = "rate_limits_config.tmpl"
+	RateLimitsConfigFileName          = "rate_limits.yaml"
+
+	IdempotencyRedisStoreTemplatePath = "idempotency_redis_store.tmpl"
+	IdempotencyRedisStoreFileName     = "idempotency_redis_store.go"
+	IdempotencySqlStoreTemplatePath   = "idempotency_sql_store.tmpl"
+	IdempotencySqlStoreFileName       = "idempotency_sql_store.go"
+	IdempotencyKeyModelTemplatePath   = "idempotency_key_model.tmpl"
+	IdempotencyKeyModelFileName       = "idempotency_key.go"
+
+	IdempotencyKeysMigrationUpTemplatePath   = "idempotency_keys_migration_up.tmpl"
+	IdempotencyKeysMigrationDownTemplatePath = "idempotency_keys_migration_down.tmpl"
+	IdempotencyKeysMigrationName             = "idempotency_keys"
+
+	AuthJwtTemplatePath  = "auth_jwt.tmpl"
+	AuthJwtFileName      = "jwt.go"
+	AuthKeysTemplatePath = "auth_keys.tmpl"
+	AuthKeysFileName     = "keys.go"
 )
@@ -1,13 +1,20 @@
 package middleware_utils
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/davidh16/goblin/cli_config"
 	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/apperrors_utils"
+	"github.com/davidh16/goblin/utils/config_utils"
+	"github.com/davidh16/goblin/utils/database_utils"
 	"github.com/davidh16/goblin/utils/logger_utils"
+	"github.com/davidh16/goblin/utils/migration_utils"
+	"github.com/davidh16/goblin/utils/observability_utils"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -18,13 +25,18 @@ import (
 	"text/template"
 )
 
-var MiddlewareOptions = []string{"RecoverMiddleware", "JwtMiddleware", "LoggingMiddleware", "RateLimiterMiddleware", "AllowOriginMiddleware"}
+var MiddlewareOptions = []string{"RecoverMiddleware", "JwtMiddleware", "LoggingMiddleware", "RateLimiterMiddleware", "AllowOriginMiddleware", "RbacMiddleware", "ApiKeyMiddleware", "IdempotencyMiddleware", "RequestIDMiddleware", "MetricsMiddleware"}
 
 var MiddlewareOptionTemplatePathMap = map[string]string{
 	"LoggingMiddleware":     LoggingMiddlewareTemplatePath,
 	"JwtMiddleware":         JwtMiddlewareTemplatePath,
 	"AllowOriginMiddleware": AllowOriginMiddlewareTemplatePath,
 	"RateLimiterMiddleware": RateLimiterMiddlewareTemplatePath,
+	"RbacMiddleware":        RbacMiddlewareTemplatePath,
+	"ApiKeyMiddleware":      ApiKeyMiddlewareTemplatePath,
+	"IdempotencyMiddleware": IdempotencyMiddlewareTemplatePath,
+	"RequestIDMiddleware":   RequestIDMiddlewareTemplatePath,
+	"MetricsMiddleware":     MetricsMiddlewareTemplatePath,
 }
 
 var MiddlewareOptionTemplateFileNameMap = map[string]string{
@@ -32,6 +44,11 @@ var MiddlewareOptionTemplateFileNameMap = map[string]string{
 	"JwtMiddleware":         "jwt_middleware.go",
 	"AllowOriginMiddleware": "allow_origin_middleware.go",
 	"RateLimiterMiddleware": "rate_limiter_middleware.go",
+	"RbacMiddleware":        "rbac_middleware.go",
+	"ApiKeyMiddleware":      "api_key_middleware.go",
+	"IdempotencyMiddleware": "idempotency_middleware.go",
+	"RequestIDMiddleware":   "request_id_middleware.go",
+	"MetricsMiddleware":     "metrics_middleware.go",
 }
 
 func GenerateMiddlewares(middlewareOptions []string) error {
@@ -43,13 +60,38 @@ func GenerateMiddlewares(middlewareOptions []string) error {
 	}
 
 	templateData := struct {
-		MiddlewaresPackage  string
-		LoggerPackage       string
-		LoggerPackageImport string
+		MiddlewaresPackage     string
+		LoggerPackage          string
+		LoggerPackageImport    string
+		AppErrorsPackage       string
+		AppErrorsPackageImport string
+		AuthPackage            string
+		AuthPackageImport      string
+		ModelsPackage          string
+		ModelsPackageImport    string
+		TelemetryPackage       string
+		TelemetryPackageImport string
+		ConfigPackage          string
+		ConfigPackageImport    string
+		AuthImplemented        bool
+		AuthRoutesImplemented  bool
 	}{
-		MiddlewaresPackage:  strings.Split(cli_config.CliConfig.MiddlewaresFolderPath, "/")[len(strings.Split(cli_config.CliConfig.MiddlewaresFolderPath, "/"))-1],
-		LoggerPackage:       strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
-		LoggerPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.LoggerFolderPath),
+		MiddlewaresPackage:     strings.Split(cli_config.CliConfig.MiddlewaresFolderPath, "/")[len(strings.Split(cli_config.CliConfig.MiddlewaresFolderPath, "/"))-1],
+		LoggerPackage:          strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
+		LoggerPackageImport:    path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.LoggerFolderPath),
+		AppErrorsPackage:       apperrors_utils.AppErrorsPackage(),
+		AppErrorsPackageImport: apperrors_utils.AppErrorsPackageImport(),
+		AuthPackage:            strings.Split(cli_config.CliConfig.AuthFolderPath, "/")[len(strings.Split(cli_config.CliConfig.AuthFolderPath, "/"))-1],
+		AuthPackageImport:      path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.AuthFolderPath),
+		ModelsPackage:          strings.Split(cli_config.CliConfig.ModelsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ModelsFolderPath, "/"))-1],
+		ModelsPackageImport:    path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ModelsFolderPath),
+		TelemetryPackage:       observability_utils.TelemetryPackage(),
+		TelemetryPackageImport: observability_utils.TelemetryPackageImport(),
+		ConfigPackage:          config_utils.ConfigPackage(),
+		ConfigPackageImport:    config_utils.ConfigPackageImport(),
+		// access tokens can be verified once the jwt helpers exist, the auth routes once goblin auth has been run
+		AuthImplemented:       utils.FileExists(path.Join(cli_config.CliConfig.AuthFolderPath, AuthJwtFileName)),
+		AuthRoutesImplemented: utils.FileExists(path.Join(cli_config.CliConfig.ControllersFolderPath, "auth_controller.go")),
 	}
 
 	for _, option := range middlewareOptions {
@@ -77,29 +119,51 @@ func GenerateMiddlewares(middlewareOptions []string) error {
 			if err != nil {
 				return err
 			}
+
+			// the logging middleware resolves response statuses of returned errors through apperrors
+			err = apperrors_utils.EnsureAppErrors()
+			if err != nil {
+				return err
+			}
 		}
 
-		if option == "JwtMiddleware" {
-			err := generateAuthJwtFile()
+		if option == "RequestIDMiddleware" {
+			// the request id is stored in the context through the logger, which logs it with FromContext
+			err := logger_utils.EnsureLogger()
 			if err != nil {
 				return err
 			}
+		}
 
-			workingDirectory, err := os.Getwd()
+		if option == "MetricsMiddleware" {
+			// the middleware records requests into the registry served on the admin port
+			err := observability_utils.GenerateMetrics()
 			if err != nil {
 				return err
 			}
 
-			envFilePath := path.Join(workingDirectory, ".env")
+			// statuses of returned errors are resolved through apperrors
+			err = apperrors_utils.EnsureAppErrors()
+			if err != nil {
+				return err
+			}
+		}
 
-			envFile, err := os.OpenFile(envFilePath, os.O_CREATE|os.O_RDWR, 0644)
+		if option == "RbacMiddleware" || option == "ApiKeyMiddleware" || option == "IdempotencyMiddleware" {
+			// rejected requests are returned as apperrors, rendered as problem+json by the router
+			err := apperrors_utils.EnsureAppErrors()
 			if err != nil {
-				return errors.New("error opening environment file")
+				return err
 			}
+		}
 
-			err = utils.WriteToEnvFile(envFile, map[string]string{
-				"JWT_SECRET": "",
-			})
+		if option == "JwtMiddleware" {
+			err := GenerateAuthJwtFile()
+			if err != nil {
+				return err
+			}
+
+			err = EnsureJwtSecret()
 			if err != nil {
 				return err
 			}
@@ -125,6 +189,11 @@ func GenerateMiddlewares(middlewareOptions []string) error {
 			if err != nil {
 				return err
 			}
+
+			err = config_utils.GenerateConfig(config_utils.CorsSection)
+			if err != nil {
+				return err
+			}
 		}
 
 		tmpl, err := template.ParseFS(templates.Files, MiddlewareOptionTemplatePathMap[option])
@@ -142,11 +211,173 @@ func GenerateMiddlewares(middlewareOptions []string) error {
 			return err
 		}
 
+		if option == "RateLimiterMiddleware" {
+			err = generateRateLimitPolicies(templateData)
+			if err != nil {
+				return err
+			}
+		}
+
+		if option == "IdempotencyMiddleware" {
+			err = generateIdempotencyStore(templateData)
+			if err != nil {
+				return err
+			}
+		}
+
 		fmt.Println(fmt.Sprintf("✅ %s generated successfully.", option))
 	}
 	return nil
 }
 
+// RedisImplemented reports whether the Redis connector has been generated by goblin database
+func RedisImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, database_utils.DatabaseOptionInstanceDefaultFileNamesMap[database_utils.Redis]))
+}
+
+// RateLimitPoliciesImplemented reports whether the rate limiter applies the policies of rate_limits.yaml
+func RateLimitPoliciesImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.MiddlewaresFolderPath, RateLimitPolicyFileName))
+}
+
+// RedisRateLimiterImplemented reports whether the rate limiter counts requests in Redis
+func RedisRateLimiterImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.MiddlewaresFolderPath, RateLimiterRedisStoreFileName))
+}
+
+// generateRateLimitPolicies renders the policy middleware and its rate_limits.yaml, which is only generated once so tuned limits are kept.
+// Limits are counted in Redis when the project has it, so they are shared by all replicas.
+func generateRateLimitPolicies(templateData any) error {
+	err := config_utils.GenerateConfig(config_utils.RateLimitSection)
+	if err != nil {
+		return err
+	}
+
+	err = renderTemplate(RateLimitPolicyTemplatePath, path.Join(cli_config.CliConfig.MiddlewaresFolderPath, RateLimitPolicyFileName), templateData)
+	if err != nil {
+		return err
+	}
+
+	if RedisImplemented() {
+		err = renderTemplate(RateLimiterRedisStoreTemplatePath, path.Join(cli_config.CliConfig.MiddlewaresFolderPath, RateLimiterRedisStoreFileName), templateData)
+		if err != nil {
+			return err
+		}
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	if !utils.FileExists(path.Join(workingDirectory, RateLimitsConfigFileName)) {
+		err = renderTemplate(RateLimitsConfigTemplatePath, path.Join(workingDirectory, RateLimitsConfigFileName), templateData)
+		if err != nil {
+			return err
+		}
+	}
+
+	envFile, err := os.OpenFile(path.Join(workingDirectory, ".env"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return errors.New("error opening environment file")
+	}
+	defer envFile.Close()
+
+	existingEnv, err := utils.ReadEnvFile(envFile)
+	if err != nil {
+		return err
+	}
+
+	// values already tuned are kept
+	rateLimitEnv := map[string]string{}
+	for key, value := range map[string]string{
+		"RATE_LIMIT_POLICIES_FILE": RateLimitsConfigFileName,
+		"RATE_LIMIT_LIMIT":         "100",
+		"RATE_LIMIT_WINDOW":        "1m",
+	} {
+		if _, exists := existingEnv[key]; !exists {
+			rateLimitEnv[key] = value
+		}
+	}
+
+	if len(rateLimitEnv) == 0 {
+		return nil
+	}
+
+	return utils.WriteToEnvFile(envFile, rateLimitEnv)
+}
+
+// SqlDatabaseImplemented reports whether a PostgreSQL or MariaDB connector has been generated by goblin database
+func SqlDatabaseImplemented() bool {
+	for _, database := range []database_utils.DatabaseOption{database_utils.PostgresSQL, database_utils.MariaDB} {
+		if utils.FileExists(path.Join(cli_config.CliConfig.DatabaseInstancesFolderPath, database_utils.DatabaseOptionInstanceDefaultFileNamesMap[database])) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedisIdempotencyImplemented reports whether idempotent responses are kept in Redis
+func RedisIdempotencyImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.MiddlewaresFolderPath, IdempotencyRedisStoreFileName))
+}
+
+// SqlIdempotencyImplemented reports whether idempotent responses are kept in the idempotency_keys table
+func SqlIdempotencyImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.MiddlewaresFolderPath, IdempotencySqlStoreFileName))
+}
+
+// generateIdempotencyStore renders the store idempotent responses are kept in, Redis when the project has it and the SQL database otherwise
+func generateIdempotencyStore(templateData any) error {
+	if RedisImplemented() {
+		return renderTemplate(IdempotencyRedisStoreTemplatePath, path.Join(cli_config.CliConfig.MiddlewaresFolderPath, IdempotencyRedisStoreFileName), templateData)
+	}
+
+	if !SqlDatabaseImplemented() {
+		return errors.New("IdempotencyMiddleware stores responses in Redis or in the SQL database, run goblin database first")
+	}
+
+	err := os.MkdirAll(cli_config.CliConfig.ModelsFolderPath, 0755)
+	if err != nil {
+		return err
+	}
+
+	modelPath := path.Join(cli_config.CliConfig.ModelsFolderPath, IdempotencyKeyModelFileName)
+	if !utils.FileExists(modelPath) {
+		err = renderTemplate(IdempotencyKeyModelTemplatePath, modelPath, templateData)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = migration_utils.GenerateTemplatedMigrationFiles(IdempotencyKeysMigrationName, IdempotencyKeysMigrationUpTemplatePath, IdempotencyKeysMigrationDownTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	return renderTemplate(IdempotencySqlStoreTemplatePath, path.Join(cli_config.CliConfig.MiddlewaresFolderPath, IdempotencySqlStoreFileName), templateData)
+}
+
+func renderTemplate(templatePath, filePath string, templateData any) error {
+	tmpl, err := template.ParseFS(templates.Files, templatePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = tmpl.Execute(f, templateData)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
 func ListExistingMiddlewares() ([]string, error) {
 
 	var middlewares []string
@@ -181,29 +412,80 @@ func ListExistingMiddlewares() ([]string, error) {
 
 }
 
-func generateAuthJwtFile() error {
-
-	if exists := utils.FileExists(cli_config.CliConfig.AuthFolderPath); !exists {
-		err := os.MkdirAll(cli_config.CliConfig.AuthFolderPath, os.ModePerm)
-		if err != nil {
-			return err
-		}
+// EnsureJwtSecret writes a random JWT_SECRET to the .env file unless one is already set
+func EnsureJwtSecret() error {
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return err
 	}
 
-	tmpl, err := template.ParseFS(templates.Files, AuthJwtTemplatePath)
+	envFile, err := os.OpenFile(path.Join(workingDirectory, ".env"), os.O_CREATE|os.O_RDWR, 0644)
 	if err != nil {
-		return err
+		return errors.New("error opening environment file")
 	}
+	defer envFile.Close()
 
-	f, err := os.Create(path.Join(cli_config.CliConfig.AuthFolderPath, "jwt.go"))
+	existingEnv, err := utils.ReadEnvFile(envFile)
 	if err != nil {
 		return err
 	}
 
-	err = tmpl.Execute(f, nil)
+	if existingEnv["JWT_SECRET"] != "" {
+		return nil
+	}
+
+	secret := make([]byte, 64)
+	_, err = rand.Read(secret)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return utils.WriteToEnvFile(envFile, map[string]string{
+		"JWT_SECRET": base64.RawURLEncoding.EncodeToString(secret),
+	})
+}
+
+// GenerateAuthJwtFile generates the auth package holding the claims, the token helpers and the signing keys used by JwtMiddleware
+func GenerateAuthJwtFile() error {
+
+	if exists := utils.FileExists(cli_config.CliConfig.AuthFolderPath); !exists {
+		err := os.MkdirAll(cli_config.CliConfig.AuthFolderPath, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+
+	templateData := struct {
+		AuthPackage         string
+		ConfigPackage       string
+		ConfigPackageImport string
+	}{
+		AuthPackage:         strings.Split(cli_config.CliConfig.AuthFolderPath, "/")[len(strings.Split(cli_config.CliConfig.AuthFolderPath, "/"))-1],
+		ConfigPackage:       config_utils.ConfigPackage(),
+		ConfigPackageImport: config_utils.ConfigPackageImport(),
+	}
+
+	for templatePath, fileName := range map[string]string{
+		AuthJwtTemplatePath:  AuthJwtFileName,
+		AuthKeysTemplatePath: AuthKeysFileName,
+	} {
+		tmpl, err := template.ParseFS(templates.Files, templatePath)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(path.Join(cli_config.CliConfig.AuthFolderPath, fileName))
+		if err != nil {
+			return err
+		}
+
+		err = tmpl.Execute(f, templateData)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	// the signing method, the secret and the token lifetimes are read from the config
+	return config_utils.GenerateConfig(config_utils.JwtSection)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/davidh16/goblin/utils"
 	"os"
 	"path"
+	"path/filepath"
 	"text/template"
 	"time"
 )
@@ -125,3 +126,54 @@ func createUuidOsspMigrations() error {
 
 	return nil
 }
+
+// GenerateTemplatedMigrationFiles generates up and down migrations of a table owned by a generated feature, i.e refresh_tokens.
+// The migrations are rendered from the given templates and are not generated again if a migration with the same name already exists.
+func GenerateTemplatedMigrationFiles(name, upTemplatePath, downTemplatePath string) error {
+	return GenerateTemplatedMigrationFilesWithData(name, upTemplatePath, downTemplatePath, nil)
+}
+
+// GenerateTemplatedMigrationFilesWithData works like GenerateTemplatedMigrationFiles and renders the templates with templateData,
+// i.e to pick the column types of the database the table is created in
+func GenerateTemplatedMigrationFilesWithData(name, upTemplatePath, downTemplatePath string, templateData any) error {
+	existingMigrations, err := filepath.Glob(path.Join(cli_config.CliConfig.MigrationsFolderPath, "*_"+name+"_up.sql"))
+	if err != nil {
+		return err
+	}
+
+	if len(existingMigrations) > 0 {
+		return nil
+	}
+
+	if exists := utils.FileExists(cli_config.CliConfig.MigrationsFolderPath); !exists {
+		err = os.MkdirAll(cli_config.CliConfig.MigrationsFolderPath, 0755) // 0755 = rwxr-xr-x
+		if err != nil {
+			return err
+		}
+	}
+
+	migrationData := GenerateMigrationDataFromName(name)
+
+	for templatePath, filePath := range map[string]string{
+		upTemplatePath:   migrationData.MigrationUpFileFullPath,
+		downTemplatePath: migrationData.MigrationDownFileFullPath,
+	} {
+		tmpl, err := template.ParseFS(templates.Files, templatePath)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(filePath)
+		if err != nil {
+			return err
+		}
+
+		err = tmpl.Execute(f, templateData)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
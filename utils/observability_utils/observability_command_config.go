@@ -0,0 +1,15 @@
+package observability_utils
+
+const (
+	TracingTemplatePath = "tracing.tmpl"
+	TracingFileName     = "tracing.go"
+
+	TracingMiddlewareTemplatePath = "tracing_middleware.tmpl"
+	TracingMiddlewareFileName     = "tracing_middleware.go"
+
+	MetricsTemplatePath = "metrics.tmpl"
+	MetricsFileName     = "metrics.go"
+)
+
+// TracesExporterOptions are the values of OTEL_TRACES_EXPORTER understood by the generated InitTracer
+var TracesExporterOptions = []string{"otlp", "console", "none"}
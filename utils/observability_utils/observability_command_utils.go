@@ -0,0 +1,200 @@
+package observability_utils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/templates"
+	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/config_utils"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// TelemetryPackage returns the package name of the generated telemetry package
+func TelemetryPackage() string {
+	return strings.Split(cli_config.CliConfig.TelemetryFolderPath, "/")[len(strings.Split(cli_config.CliConfig.TelemetryFolderPath, "/"))-1]
+}
+
+// TelemetryPackageImport returns the import path of the generated telemetry package
+func TelemetryPackageImport() string {
+	return path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.TelemetryFolderPath)
+}
+
+// TracingImplemented reports whether the tracer setup has been generated by goblin observability.
+// Database connectors, the jobs manager and worker pools generated afterward are instrumented when it has.
+func TracingImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.TelemetryFolderPath, TracingFileName))
+}
+
+// TracingMiddlewareImplemented reports whether the echo tracing middleware has been generated, the router injects it whenever it has
+func TracingMiddlewareImplemented() bool {
+	return TracingImplemented() && utils.FileExists(path.Join(cli_config.CliConfig.MiddlewaresFolderPath, TracingMiddlewareFileName))
+}
+
+// MetricsImplemented reports whether the Prometheus metrics have been generated with the MetricsMiddleware,
+// main.go serves them on the admin port and the orchestrator records job metrics when they have
+func MetricsImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.TelemetryFolderPath, MetricsFileName))
+}
+
+// GenerateTracing generates the tracer setup, the echo tracing middleware and the OTEL_* env values
+func GenerateTracing(tracesExporter string) error {
+	err := os.MkdirAll(cli_config.CliConfig.TelemetryFolderPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(cli_config.CliConfig.MiddlewaresFolderPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	templateData := struct {
+		ProjectName            string
+		TelemetryPackage       string
+		TelemetryPackageImport string
+		MiddlewaresPackage     string
+		ConfigPackage          string
+		ConfigPackageImport    string
+	}{
+		ProjectName:            cli_config.CliConfig.ProjectName,
+		TelemetryPackage:       TelemetryPackage(),
+		TelemetryPackageImport: TelemetryPackageImport(),
+		MiddlewaresPackage:     strings.Split(cli_config.CliConfig.MiddlewaresFolderPath, "/")[len(strings.Split(cli_config.CliConfig.MiddlewaresFolderPath, "/"))-1],
+		ConfigPackage:          config_utils.ConfigPackage(),
+		ConfigPackageImport:    config_utils.ConfigPackageImport(),
+	}
+
+	err = renderTemplate(TracingTemplatePath, path.Join(cli_config.CliConfig.TelemetryFolderPath, TracingFileName), templateData)
+	if err != nil {
+		return err
+	}
+
+	err = renderTemplate(TracingMiddlewareTemplatePath, path.Join(cli_config.CliConfig.MiddlewaresFolderPath, TracingMiddlewareFileName), templateData)
+	if err != nil {
+		return err
+	}
+
+	err = config_utils.GenerateConfig(config_utils.TracingSection)
+	if err != nil {
+		return err
+	}
+
+	return writeTracingEnv(tracesExporter)
+}
+
+// writeTracingEnv writes the OTEL_* values missing from the .env file, the exporter is always set to the selected one
+func writeTracingEnv(tracesExporter string) error {
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	envFile, err := os.OpenFile(path.Join(workingDirectory, ".env"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return errors.New("error opening environment file")
+	}
+	defer envFile.Close()
+
+	existingEnv, err := utils.ReadEnvFile(envFile)
+	if err != nil {
+		return err
+	}
+
+	tracingEnv := map[string]string{
+		"OTEL_TRACES_EXPORTER": tracesExporter,
+	}
+	for key, value := range map[string]string{
+		"OTEL_SERVICE_NAME":           cli_config.CliConfig.ProjectName,
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+		"OTEL_TRACES_SAMPLER":         "parentbased_always_on",
+	} {
+		if _, exists := existingEnv[key]; !exists {
+			tracingEnv[key] = value
+		}
+	}
+
+	return utils.WriteToEnvFile(envFile, tracingEnv)
+}
+
+// GenerateMetrics generates the Prometheus registry with the request, database pool and job metrics, and the admin port env values
+func GenerateMetrics() error {
+	err := os.MkdirAll(cli_config.CliConfig.TelemetryFolderPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	templateData := struct {
+		TelemetryPackage string
+	}{
+		TelemetryPackage: TelemetryPackage(),
+	}
+
+	err = renderTemplate(MetricsTemplatePath, path.Join(cli_config.CliConfig.TelemetryFolderPath, MetricsFileName), templateData)
+	if err != nil {
+		return err
+	}
+
+	// main.go reads the admin address from the config
+	err = config_utils.GenerateConfig(config_utils.MetricsSection)
+	if err != nil {
+		return err
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	envFile, err := os.OpenFile(path.Join(workingDirectory, ".env"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return errors.New("error opening environment file")
+	}
+	defer envFile.Close()
+
+	existingEnv, err := utils.ReadEnvFile(envFile)
+	if err != nil {
+		return err
+	}
+
+	// the admin port is kept apart from SERVER_BIND_PORT, so /metrics is never exposed with the public routes,
+	// and from 9090, the port Prometheus itself and a lot of gRPC servers listen on
+	metricsEnv := map[string]string{}
+	for key, value := range map[string]string{
+		"METRICS_BIND_ADDRESS": "",
+		"METRICS_BIND_PORT":    "9100",
+	} {
+		if _, exists := existingEnv[key]; !exists {
+			metricsEnv[key] = value
+		}
+	}
+
+	if len(metricsEnv) == 0 {
+		return nil
+	}
+
+	return utils.WriteToEnvFile(envFile, metricsEnv)
+}
+
+func renderTemplate(templatePath, filePath string, templateData any) error {
+	tmpl, err := template.ParseFS(templates.Files, templatePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = tmpl.Execute(f, templateData)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", filePath, err)
+	}
+
+	return nil
+}
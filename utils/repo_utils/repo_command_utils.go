@@ -5,6 +5,7 @@ import (
 	"github.com/davidh16/goblin/cli_config"
 	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/apperrors_utils"
 	"github.com/davidh16/goblin/utils/model_utils"
 	"github.com/jinzhu/inflection"
 	"go/ast"
@@ -284,50 +285,20 @@ func AddMethodsToRepo(repoData *RepoData, wantedRepoMethods []Method) error {
 	var newDecls []ast.Decl
 
 	// Prepare model import path
-	modelsPackage := path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ModelsFolderPath)
-	quotedModelsPackage := strconv.Quote(modelsPackage)
+	addImport(node, path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ModelsFolderPath))
 
-	importSpec := &ast.ImportSpec{
-		Path: &ast.BasicLit{
-			Kind:  token.STRING,
-			Value: quotedModelsPackage,
-		},
-	}
-
-	var importAdded bool
-
-	// Check if import block exists and append to it
-	for _, decl := range node.Decls {
-		genDecl, ok := decl.(*ast.GenDecl)
-		if !ok || genDecl.Tok != token.IMPORT {
+	// every method apart from ListWithPagination translates gorm errors into domain errors
+	for _, repoMethod := range wantedRepoMethods {
+		if repoMethod == ListWithPagination {
 			continue
 		}
 
-		// Check if already imported
-		for _, spec := range genDecl.Specs {
-			if importSpecTyped, ok := spec.(*ast.ImportSpec); ok && importSpecTyped.Path.Value == quotedModelsPackage {
-				importAdded = true
-				break
-			}
-		}
-
-		// Append to existing import block if not present
-		if !importAdded {
-			genDecl.Specs = append(genDecl.Specs, importSpec)
-			importAdded = true
-			break
-		}
-	}
-
-	// If no import block exists, create a new one at the top
-	if !importAdded {
-		newImportDecl := &ast.GenDecl{
-			Tok: token.IMPORT,
-			Specs: []ast.Spec{
-				importSpec,
-			},
+		err = apperrors_utils.EnsureAppErrors()
+		if err != nil {
+			return err
 		}
-		node.Decls = append([]ast.Decl{newImportDecl}, node.Decls...)
+		addImport(node, apperrors_utils.AppErrorsPackageImport())
+		break
 	}
 
 	// Update interface with method signatures
@@ -375,6 +346,55 @@ func AddMethodsToRepo(repoData *RepoData, wantedRepoMethods []Method) error {
 	return nil
 }
 
+// addImport adds the import path to the import block of the file unless it is already imported.
+// If the file has no import block, a new one is created at the top.
+func addImport(node *ast.File, importPath string) {
+	quotedImportPath := strconv.Quote(importPath)
+
+	importSpec := &ast.ImportSpec{
+		Path: &ast.BasicLit{
+			Kind:  token.STRING,
+			Value: quotedImportPath,
+		},
+	}
+
+	var importAdded bool
+
+	// Check if import block exists and append to it
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+
+		// Check if already imported
+		for _, spec := range genDecl.Specs {
+			if importSpecTyped, ok := spec.(*ast.ImportSpec); ok && importSpecTyped.Path.Value == quotedImportPath {
+				importAdded = true
+				break
+			}
+		}
+
+		// Append to existing import block if not present
+		if !importAdded {
+			genDecl.Specs = append(genDecl.Specs, importSpec)
+			importAdded = true
+			break
+		}
+	}
+
+	// If no import block exists, create a new one at the top
+	if !importAdded {
+		newImportDecl := &ast.GenDecl{
+			Tok: token.IMPORT,
+			Specs: []ast.Spec{
+				importSpec,
+			},
+		}
+		node.Decls = append([]ast.Decl{newImportDecl}, node.Decls...)
+	}
+}
+
 // NewRepoMethod generates both the interface method signature and the
 // implementation (function declaration) for a given repository method.
 //
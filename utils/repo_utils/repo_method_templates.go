@@ -2,6 +2,7 @@ package repo_utils
 
 import (
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/apperrors_utils"
 	"github.com/jinzhu/inflection"
 	"go/ast"
 	"go/token"
@@ -9,6 +10,17 @@ import (
 
 //////// bodies
 
+// generateTranslatedError returns apperrors.FromGorm(err) so repo methods return domain errors instead of raw gorm errors
+var generateTranslatedError = func() ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent(apperrors_utils.AppErrorsPackage()),
+			Sel: ast.NewIdent("FromGorm"),
+		},
+		Args: []ast.Expr{ast.NewIdent("err")},
+	}
+}
+
 var generateCreateMethodBody = func(modelPascalCase string) []ast.Stmt {
 	return []ast.Stmt{
 		&ast.AssignStmt{
@@ -38,7 +50,7 @@ var generateCreateMethodBody = func(modelPascalCase string) []ast.Stmt {
 					&ast.ReturnStmt{
 						Results: []ast.Expr{
 							ast.NewIdent("nil"),
-							ast.NewIdent("err"),
+							generateTranslatedError(),
 						},
 					},
 				},
@@ -82,7 +94,7 @@ var generateUpdateMethodBody = func(modelPascalCase string) []ast.Stmt {
 					&ast.ReturnStmt{
 						Results: []ast.Expr{
 							ast.NewIdent("nil"),
-							ast.NewIdent("err"),
+							generateTranslatedError(),
 						},
 					},
 				},
@@ -125,7 +137,7 @@ var generateDeleteMethodBody = func(modelPascalCase string) []ast.Stmt {
 				List: []ast.Stmt{
 					&ast.ReturnStmt{
 						Results: []ast.Expr{
-							ast.NewIdent("err"),
+							generateTranslatedError(),
 						},
 					},
 				},
@@ -184,7 +196,7 @@ var generateListAllMethodBody = func(modelPascalCase, modelDataType string) []as
 					&ast.ReturnStmt{
 						Results: []ast.Expr{
 							ast.NewIdent("nil"),
-							ast.NewIdent("err"),
+							generateTranslatedError(),
 						},
 					},
 				},
@@ -218,7 +230,7 @@ var generateGetByUuidMethodBody = func(modelPascalCase, modelDataType string) []
 				Specs: []ast.Spec{
 					&ast.ValueSpec{
 						Names: []*ast.Ident{ast.NewIdent(utils.PascalToCamel(modelPascalCase))},
-						Type:  ast.NewIdent("*" + modelDataType),
+						Type:  ast.NewIdent(modelDataType),
 					},
 				},
 			},
@@ -243,7 +255,10 @@ var generateGetByUuidMethodBody = func(modelPascalCase, modelDataType string) []
 							Sel: ast.NewIdent("First"),
 						},
 						Args: []ast.Expr{
-							ast.NewIdent(utils.PascalToCamel(modelPascalCase)),
+							&ast.UnaryExpr{
+								Op: token.AND,
+								X:  ast.NewIdent(utils.PascalToCamel(modelPascalCase)),
+							},
 						},
 					},
 					Sel: ast.NewIdent("Error"),
@@ -261,7 +276,7 @@ var generateGetByUuidMethodBody = func(modelPascalCase, modelDataType string) []
 					&ast.ReturnStmt{
 						Results: []ast.Expr{
 							ast.NewIdent("nil"),
-							ast.NewIdent("err"),
+							generateTranslatedError(),
 						},
 					},
 				},
@@ -269,7 +284,10 @@ var generateGetByUuidMethodBody = func(modelPascalCase, modelDataType string) []
 		},
 		&ast.ReturnStmt{
 			Results: []ast.Expr{
-				ast.NewIdent(utils.PascalToCamel(modelPascalCase)),
+				&ast.UnaryExpr{
+					Op: token.AND,
+					X:  ast.NewIdent(utils.PascalToCamel(modelPascalCase)),
+				},
 				ast.NewIdent("nil"),
 			},
 		},
@@ -3,4 +3,5 @@ package router_utils
 const (
 	RouterTemplatePath       = "router.tmpl"
 	CustomBinderTemplatePath = "custom_request_binder.tmpl"
+	HealthTemplatePath       = "health.tmpl"
 )
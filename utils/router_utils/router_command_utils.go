@@ -1,11 +1,22 @@
 package router_utils
 
 import (
+	"fmt"
 	"github.com/davidh16/goblin/cli_config"
 	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
+	"github.com/davidh16/goblin/utils/apperrors_utils"
+	"github.com/davidh16/goblin/utils/auth_utils"
+	"github.com/davidh16/goblin/utils/middleware_utils"
+	"github.com/davidh16/goblin/utils/observability_utils"
+	"github.com/samber/lo"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 )
@@ -15,13 +26,53 @@ type RouterData struct {
 	RecoverMiddleware     bool
 	RateLimiterMiddleware bool
 	AllowOriginMiddleware bool
+	IdempotencyMiddleware bool
+	RequestIDMiddleware   bool
+	MetricsMiddleware     bool
 	ImplementMiddlewares  bool
+	AuthImplemented       bool
 }
 
+// RouteData is a controller method annotated with @route, i.e
+//
+//	// @route POST /orders
+//	// @permission orders:write
+//	func (oc *OrderController) Create(c echo.Context) error
+type RouteData struct {
+	Method     string // i.e POST
+	Path       string // i.e /orders, relative to the /api group
+	Controller string // i.e OrderController
+	Handler    string // i.e Create
+	Permission string // i.e orders:write, optional
+}
+
+var routeMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
 func NewRouterData() *RouterData {
 	return &RouterData{}
 }
 
+// DetectRouterData resolves router data from the existing router.go, so regenerating the router keeps the middlewares already injected into it
+func DetectRouterData() *RouterData {
+	routerData := NewRouterData()
+
+	routerFile, err := os.ReadFile(path.Join(cli_config.CliConfig.RouterFolderPath, "router.go"))
+	if err == nil {
+		routerData.RecoverMiddleware = strings.Contains(string(routerFile), "middleware.Recover()")
+		routerData.LoggingMiddleware = strings.Contains(string(routerFile), ".LoggingMiddleware")
+		routerData.RateLimiterMiddleware = strings.Contains(string(routerFile), ".NewRateLimiterMiddleware(") || strings.Contains(string(routerFile), ".NewRedisRateLimiterConfig(") || strings.Contains(string(routerFile), ".NewRateLimitPolicyMiddleware(")
+		routerData.AllowOriginMiddleware = strings.Contains(string(routerFile), ".AllowOriginMiddleware")
+		routerData.IdempotencyMiddleware = strings.Contains(string(routerFile), ".NewIdempotencyMiddleware(")
+		routerData.RequestIDMiddleware = strings.Contains(string(routerFile), ".RequestIDMiddleware()")
+		routerData.MetricsMiddleware = strings.Contains(string(routerFile), ".MetricsMiddleware()")
+	}
+
+	routerData.AuthImplemented = auth_utils.AuthImplemented()
+	routerData.ImplementMiddlewares = routerData.LoggingMiddleware || routerData.RateLimiterMiddleware || routerData.AllowOriginMiddleware || routerData.IdempotencyMiddleware || routerData.RequestIDMiddleware || routerData.MetricsMiddleware || routerData.AuthImplemented
+
+	return routerData
+}
+
 func GenerateRouter(routerData *RouterData) error {
 
 	if !utils.FileExists(cli_config.CliConfig.RouterFolderPath) {
@@ -31,6 +82,40 @@ func GenerateRouter(routerData *RouterData) error {
 		}
 	}
 
+	err := apperrors_utils.EnsureAppErrors()
+	if err != nil {
+		return err
+	}
+
+	routes, err := ParseRouteAnnotations()
+	if err != nil {
+		return err
+	}
+
+	// permissions are checked against the roles carried by the access token, a route requiring one is never mounted without them
+	var unenforcedRoutes []string
+	for _, route := range routes {
+		if route.Permission == "" {
+			continue
+		}
+
+		if !routerData.AuthImplemented || !auth_utils.RbacImplemented() {
+			unenforcedRoutes = append(unenforcedRoutes, fmt.Sprintf("%s.%s (%s)", route.Controller, route.Handler, route.Permission))
+			continue
+		}
+
+		routerData.ImplementMiddlewares = true
+	}
+
+	if len(unenforcedRoutes) > 0 {
+		return fmt.Errorf("routes %s require a permission but auth or RBAC is not implemented, run goblin auth and goblin middleware with RbacMiddleware first", strings.Join(unenforcedRoutes, ", "))
+	}
+
+	// once goblin observability has been run every router traces requests
+	if observability_utils.TracingMiddlewareImplemented() {
+		routerData.ImplementMiddlewares = true
+	}
+
 	tmpl, err := template.ParseFS(templates.Files, RouterTemplatePath)
 	if err != nil {
 		return err
@@ -50,9 +135,25 @@ func GenerateRouter(routerData *RouterData) error {
 		RecoverMiddleware        bool
 		AllowOriginMiddleware    bool
 		RateLimiterMiddleware    bool
+		RateLimitPolicies        bool
+		RedisRateLimiter         bool
+		IdempotencyMiddleware    bool
+		RedisIdempotency         bool
+		RouterRequiresRedis      bool
+		RouterRequiresDatabase   bool
+		RequestIDMiddleware      bool
+		TracingMiddleware        bool
+		MetricsMiddleware        bool
+		EchoMiddlewareImported   bool
 		LoggingMiddleware        bool
 		ControllersPackageImport string
 		ControllersPackage       string
+		AppErrorsPackage         string
+		AppErrorsPackageImport   string
+		AuthImplemented          bool
+		AuthPackage              string
+		AuthPackageImport        string
+		Routes                   []RouteData
 	}{
 		RouterPackage:            strings.Split(cli_config.CliConfig.RouterFolderPath, "/")[len(strings.Split(cli_config.CliConfig.RouterFolderPath, "/"))-1],
 		ImplementMiddlewares:     routerData.ImplementMiddlewares,
@@ -61,11 +162,29 @@ func GenerateRouter(routerData *RouterData) error {
 		RecoverMiddleware:        routerData.RecoverMiddleware,
 		AllowOriginMiddleware:    routerData.AllowOriginMiddleware,
 		RateLimiterMiddleware:    routerData.RateLimiterMiddleware,
+		RateLimitPolicies:        routerData.RateLimiterMiddleware && middleware_utils.RateLimitPoliciesImplemented(),
+		RedisRateLimiter:         routerData.RateLimiterMiddleware && middleware_utils.RateLimitPoliciesImplemented() && middleware_utils.RedisRateLimiterImplemented(),
+		IdempotencyMiddleware:    routerData.IdempotencyMiddleware,
+		RedisIdempotency:         routerData.IdempotencyMiddleware && middleware_utils.RedisIdempotencyImplemented(),
+		RequestIDMiddleware:      routerData.RequestIDMiddleware,
+		TracingMiddleware:        observability_utils.TracingMiddlewareImplemented(),
+		MetricsMiddleware:        routerData.MetricsMiddleware,
 		LoggingMiddleware:        routerData.LoggingMiddleware,
 		ControllersPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ControllersFolderPath),
 		ControllersPackage:       strings.Split(cli_config.CliConfig.ControllersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.ControllersFolderPath, "/"))-1],
+		AppErrorsPackage:         apperrors_utils.AppErrorsPackage(),
+		AppErrorsPackageImport:   apperrors_utils.AppErrorsPackageImport(),
+		AuthImplemented:          routerData.AuthImplemented,
+		AuthPackage:              auth_utils.AuthPackage(),
+		AuthPackageImport:        auth_utils.AuthPackageImport(),
+		Routes:                   routes,
 	}
 
+	// stores used by the middlewares are passed to InitRouter by main.go
+	templateData.RouterRequiresRedis = templateData.RedisRateLimiter || templateData.RedisIdempotency
+	templateData.RouterRequiresDatabase = templateData.IdempotencyMiddleware && middleware_utils.SqlIdempotencyImplemented() && !templateData.RedisIdempotency
+	templateData.EchoMiddlewareImported = !templateData.RequestIDMiddleware || templateData.RecoverMiddleware || templateData.AllowOriginMiddleware || (templateData.RateLimiterMiddleware && !templateData.RateLimitPolicies)
+
 	err = tmpl.Execute(f, templateData)
 	if err != nil {
 		return err
@@ -80,11 +199,189 @@ func GenerateRouter(routerData *RouterData) error {
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
 	err = tmpl.Execute(f, templateData)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return GenerateHealthFile()
+}
+
+// GenerateHealthFile generates the liveness and readiness probes, checks are provided for the databases goblin generated connectors for.
+// main.go registers the checks, so it is regenerated with main.go as well.
+func GenerateHealthFile() error {
+	err := os.MkdirAll(cli_config.CliConfig.RouterFolderPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.ParseFS(templates.Files, HealthTemplatePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path.Join(cli_config.CliConfig.RouterFolderPath, "health.go"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	templateData := struct {
+		RouterPackage          string
+		SqlDatabaseImplemented bool
+		RedisImplemented       bool
+	}{
+		RouterPackage:          strings.Split(cli_config.CliConfig.RouterFolderPath, "/")[len(strings.Split(cli_config.CliConfig.RouterFolderPath, "/"))-1],
+		SqlDatabaseImplemented: middleware_utils.SqlDatabaseImplemented(),
+		RedisImplemented:       middleware_utils.RedisImplemented(),
+	}
+
+	return tmpl.Execute(f, templateData)
+}
+
+// ParseRouteAnnotations collects the controller methods annotated with @route and an optional @permission.
+// Only methods of controllers registered in the central controller are collected, as routes are bound through it.
+func ParseRouteAnnotations() ([]RouteData, error) {
+	var routes []RouteData
+
+	registeredControllers, err := listCentralControllerFields()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	err = filepath.Walk(cli_config.CliConfig.ControllersFolderPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(info.Name(), ".go") || info.Name() == "central_controller.go" {
+			return nil
+		}
+
+		fileSet := token.NewFileSet()
+		node, err := parser.ParseFile(fileSet, filePath, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("failed to parse file %s: %w", filePath, err)
+		}
+
+		for _, decl := range node.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || fn.Doc == nil {
+				continue
+			}
+
+			receiver, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			receiverIdent, ok := receiver.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			route := RouteData{
+				Controller: receiverIdent.Name,
+				Handler:    fn.Name.Name,
+			}
+
+			for _, comment := range fn.Doc.List {
+				fields := strings.Fields(strings.TrimPrefix(comment.Text, "//"))
+				if len(fields) == 0 {
+					continue
+				}
+
+				switch fields[0] {
+				case "@route":
+					if len(fields) != 3 || !lo.Contains(routeMethods, strings.ToUpper(fields[1])) || !strings.HasPrefix(fields[2], "/") {
+						return fmt.Errorf("%s: invalid annotation %q, expected @route <METHOD> </path>", fileSet.Position(comment.Pos()), comment.Text)
+					}
+					route.Method = strings.ToUpper(fields[1])
+					route.Path = fields[2]
+				case "@permission":
+					if len(fields) != 2 {
+						return fmt.Errorf("%s: invalid annotation %q, expected @permission <permission>", fileSet.Position(comment.Pos()), comment.Text)
+					}
+					route.Permission = fields[1]
+				}
+			}
+
+			if route.Method == "" {
+				continue
+			}
+
+			if !lo.Contains(registeredControllers, route.Controller) {
+				fmt.Printf("⚠️ %s is not registered in the central controller, route %s %s is skipped\n", route.Controller, route.Method, route.Path)
+				continue
+			}
+
+			if !fn.Name.IsExported() {
+				return fmt.Errorf("%s: %s.%s is annotated with @route but is not exported", fileSet.Position(fn.Pos()), route.Controller, route.Handler)
+			}
+
+			routes = append(routes, route)
+		}
+
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes, nil
+}
+
+// listCentralControllerFields returns the names of the controllers held by the central controller
+func listCentralControllerFields() ([]string, error) {
+	fileSet := token.NewFileSet()
+	node, err := parser.ParseFile(fileSet, path.Join(cli_config.CliConfig.ControllersFolderPath, "central_controller.go"), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	ast.Inspect(node, func(n ast.Node) bool {
+		typeSpec, ok := n.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != "CentralController" {
+			return true
+		}
+
+		if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					fields = append(fields, name.Name)
+				}
+			}
+		}
+		return false
+	})
+
+	return fields, nil
+}
+
+// RouterRequiresRedis reports whether InitRouter of the existing router.go accepts the Redis client, so main.go can pass it in
+func RouterRequiresRedis() bool {
+	routerFile, err := os.ReadFile(path.Join(cli_config.CliConfig.RouterFolderPath, "router.go"))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(string(routerFile), "redisClient *redis.Client")
+}
+
+// RouterRequiresDatabase reports whether InitRouter of the existing router.go accepts the database connection, so main.go can pass it in
+func RouterRequiresDatabase() bool {
+	routerFile, err := os.ReadFile(path.Join(cli_config.CliConfig.RouterFolderPath, "router.go"))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(string(routerFile), "db *gorm.DB")
 }
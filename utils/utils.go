@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/google/uuid"
+	"io"
 	"os"
 	"reflect"
 	"regexp"
@@ -55,7 +56,7 @@ func Keys[K comparable, V any](m map[K]V) []K {
 func MapToString[K comparable, V any](m map[K]V) []string {
 	lines := make([]string, 0, len(m))
 	for k, v := range m {
-		lines = append(lines, fmt.Sprintf("%s: %s", k, v))
+		lines = append(lines, fmt.Sprintf("%v: %v", k, v))
 	}
 	return lines
 }
@@ -252,14 +253,11 @@ func ReadEnvFile(envFile *os.File) (map[string]string, error) {
 
 	scanner := bufio.NewScanner(envFile)
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if len(line) == 0 || strings.HasPrefix(line, "#") {
+		key, value, isVariable := ParseEnvLine(scanner.Text())
+		if !isVariable {
 			continue // skip empty lines and comments
 		}
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) == 2 {
-			env[parts[0]] = parts[1]
-		}
+		env[key] = value
 	}
 
 	// Important: rewind file to beginning
@@ -271,37 +269,82 @@ func ReadEnvFile(envFile *os.File) (map[string]string, error) {
 	return env, scanner.Err()
 }
 
-// WriteEnvFile writes the final map to the .env file
-func writeEnvFile(file *os.File, envMap map[string]string) error {
+// ParseEnvLine returns the key and the value of a KEY=value line, empty lines and comments are not variables
+func ParseEnvLine(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if len(line) == 0 || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
 
-	keys := make([]string, 0, len(envMap))
-	for k := range envMap {
-		keys = append(keys, k)
+	return strings.TrimSpace(parts[0]), parts[1], true
+}
+
+// WriteToEnvFile sets the values in the .env file, existing lines keep their place and comments,
+// new keys are appended sorted, separated from the existing ones by an empty line.
+func WriteToEnvFile(file *os.File, newEnv map[string]string) error {
+	_, err := file.Seek(0, 0)
+	if err != nil {
+		return err
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+
+	written := make(map[string]bool)
+	for i, line := range lines {
+		key, _, isVariable := ParseEnvLine(line)
+		if !isVariable {
+			continue
+		}
+		if value, exists := newEnv[key]; exists {
+			lines[i] = fmt.Sprintf("%s=%s", key, value)
+			written[key] = true
+		}
+	}
+
+	var keys []string
+	for key := range newEnv {
+		if !written[key] {
+			keys = append(keys, key)
+		}
 	}
 	sort.Strings(keys)
 
+	if len(keys) > 0 && len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+		lines = append(lines, "")
+	}
 	for _, key := range keys {
-		_, err := file.WriteString(fmt.Sprintf("%s=%s\n", key, envMap[key]))
-		if err != nil {
-			return err
-		}
+		lines = append(lines, fmt.Sprintf("%s=%s", key, newEnv[key]))
 	}
-	return nil
-}
 
-// WriteToEnvFile merges and writes new env vars
-func WriteToEnvFile(file *os.File, newEnv map[string]string) error {
-	existingEnv, err := ReadEnvFile(file)
+	err = file.Truncate(0)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Seek(0, 0)
 	if err != nil {
 		return err
 	}
 
-	// Merge with new keys (overwrite existing)
-	for k, v := range newEnv {
-		existingEnv[k] = v
+	if len(lines) == 0 {
+		return nil
 	}
 
-	return writeEnvFile(file, existingEnv)
+	_, err = file.WriteString(strings.Join(lines, "\n") + "\n")
+	return err
 }
 
 func MergeMaps(map1, map2 map[string]string) map[string]string {
@@ -310,3 +353,30 @@ func MergeMaps(map1, map2 map[string]string) map[string]string {
 	}
 	return map1
 }
+
+// AddToGitignore appends the entry to the project's .gitignore unless it is already ignored
+func AddToGitignore(entry string) error {
+	content, err := os.ReadFile(".gitignore")
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == entry {
+			return nil
+		}
+	}
+
+	f, err := os.OpenFile(".gitignore", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		entry = "\n" + entry
+	}
+
+	_, err = f.WriteString(entry + "\n")
+	return err
+}
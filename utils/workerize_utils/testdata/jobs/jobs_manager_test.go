@@ -0,0 +1,194 @@
+package jobs
+
+// This file is copied into the jobs package generated by TestGeneratedWorkersBuild,
+// it runs against the generated code with the SendEmail job added to the critical queue.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+)
+
+func newTestJobsManager(t *testing.T, server *miniredis.Miniredis) *jobManager {
+	t.Helper()
+
+	client, err := rueidis.NewClient(rueidis.ClientOption{InitAddress: []string{server.Addr()}, DisableCache: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	return NewJobsManager(client, nil).(*jobManager)
+}
+
+func newTestJob(t *testing.T) *Job {
+	t.Helper()
+
+	job, err := NewJob(JobTypeSendEmail, SendEmailJobMetadata{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func TestWeightedQueueOrder(t *testing.T) {
+	previous := queuePriorities
+	t.Cleanup(func() { queuePriorities = previous })
+	queuePriorities = map[string]int{DefaultQueue: 1, "critical": 3, "disabled": 0}
+
+	const draws = 4000
+	first := map[string]int{}
+	for i := 0; i < draws; i++ {
+		order := weightedQueueOrder()
+		if len(order) != len(queuePriorities) {
+			t.Fatalf("order %v doesn't hold every queue once", order)
+		}
+		seen := map[string]bool{}
+		for _, queue := range order {
+			if seen[queue] {
+				t.Fatalf("queue %s is polled twice in %v", queue, order)
+			}
+			seen[queue] = true
+		}
+		first[order[0]]++
+	}
+
+	// critical weighs 3 of 5, a queue with priority 0 is polled like one with priority 1
+	if share := float64(first["critical"]) / draws; share < 0.55 || share > 0.65 {
+		t.Errorf("critical is polled first %.2f of the time, want about 0.60", share)
+	}
+	if first["disabled"] == 0 {
+		t.Error("a queue with priority 0 is never polled first")
+	}
+}
+
+func TestQueueOf(t *testing.T) {
+	if queue := queueOf(JobTypeSendEmail); queue != "critical" {
+		t.Errorf("queueOf(JobTypeSendEmail) = %s, want critical", queue)
+	}
+	if queue := queueOf(JobTypeUnspecified); queue != DefaultQueue {
+		t.Errorf("queueOf(JobTypeUnspecified) = %s, want %s", queue, DefaultQueue)
+	}
+	if key := QueueKey(DefaultQueue); key != RedisJobQueue {
+		t.Errorf("QueueKey(DefaultQueue) = %s, want %s", key, RedisJobQueue)
+	}
+}
+
+func TestPromoteDueJobs(t *testing.T) {
+	server := miniredis.RunT(t)
+	jm := newTestJobsManager(t, server)
+	ctx := context.Background()
+
+	if err := jm.EnqueueIn(ctx, newTestJob(t), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.ZAdd(scheduledKey("critical"), float64(time.Now().Add(-time.Second).UnixMilli()), `{"job_type":1}`); err != nil {
+		t.Fatal(err)
+	}
+
+	promoted, err := jm.PromoteDueJobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if promoted != 1 {
+		t.Errorf("promoted %d jobs, want the due one only", promoted)
+	}
+
+	if depth, _ := jm.QueueDepth(ctx); depth != 1 {
+		t.Errorf("queue depth is %d, want 1", depth)
+	}
+	if scheduled, _ := server.ZMembers(scheduledKey("critical")); len(scheduled) != 1 {
+		t.Errorf("%d jobs are left scheduled, want the one due in an hour", len(scheduled))
+	}
+}
+
+func TestReleaseAndReap(t *testing.T) {
+	server := miniredis.RunT(t)
+	worker := newTestJobsManager(t, server)
+	reaper := newTestJobsManager(t, server)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := worker.EnqueueJob(ctx, newTestJob(t)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worker.FetchAndProcessJob(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := worker.Heartbeat(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// jobs of a process which is still heartbeating are left alone
+	reaped, err := reaper.ReapAbandonedJobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reaped != 0 {
+		t.Fatalf("reaped %d jobs of a live process", reaped)
+	}
+
+	server.FastForward(VisibilityTimeout + time.Second)
+	reaped, err = reaper.ReapAbandonedJobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reaped != 2 {
+		t.Fatalf("reaped %d jobs, want 2", reaped)
+	}
+	if members, _ := server.SMembers(redisWorkersSet); len(members) != 0 {
+		t.Errorf("the dead process is still in the workers set: %v", members)
+	}
+
+	// Release puts the jobs back even though the process is heartbeating
+	if _, err = worker.FetchAndProcessJob(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = worker.Heartbeat(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = worker.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if depth, _ := worker.QueueDepth(ctx); depth != 2 {
+		t.Errorf("queue depth is %d, want both jobs back", depth)
+	}
+	if processing, _ := server.List(processingKey("critical", worker.workerId)); len(processing) != 0 {
+		t.Errorf("%d jobs are left in the processing list", len(processing))
+	}
+	if server.Exists(redisHeartbeatKeyPrefix + worker.workerId) {
+		t.Error("the heartbeat of the released process is kept")
+	}
+}
+
+func TestAckJobRemovesTheTakenJob(t *testing.T) {
+	server := miniredis.RunT(t)
+	jm := newTestJobsManager(t, server)
+	ctx := context.Background()
+
+	if err := jm.EnqueueJob(ctx, newTestJob(t)); err != nil {
+		t.Fatal(err)
+	}
+	job, err := jm.FetchAndProcessJob(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = jm.AckJob(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if err = jm.AckJob(ctx, job); err == nil {
+		t.Error("a job acknowledged twice isn't reported")
+	}
+
+	if processing, _ := server.List(processingKey("critical", jm.workerId)); len(processing) != 0 {
+		t.Errorf("%d jobs are left in the processing list", len(processing))
+	}
+	if depth, _ := jm.QueueDepth(ctx); depth != 0 {
+		t.Errorf("queue depth is %d, want 0", depth)
+	}
+}
@@ -8,4 +8,28 @@ const (
 	CustomJobMetadataTemplateFilePath = "custom_job_metadata.tmpl"
 	CustomWorkerPoolTemplateFilePath  = "custom_worker_pool.tmpl"
 	CustomWorkerPoolTemplateName      = "custom_worker_pool.tmpl"
+	QueuesTemplateFilePath            = "queues.tmpl"
+	SchedulerTemplateFilePath         = "scheduler.tmpl"
+	SchedulesTemplateFilePath         = "schedules.tmpl"
+	JobsAdminTemplateFilePath         = "jobs_admin.tmpl"
+)
+
+const (
+	// JobsMigrationName is the table failed jobs are saved to by the jobs manager
+	JobsMigrationName             = "jobs"
+	JobsMigrationUpTemplatePath   = "jobs_migration_up.tmpl"
+	JobsMigrationDownTemplatePath = "jobs_migration_down.tmpl"
+	JobsAdminFileName             = "admin.go"
+)
+
+const (
+	// DefaultQueue is the queue of the jobs generated before queues were introduced, its name is kept by the DefaultQueue constant of queues.go
+	DefaultQueue         = "default"
+	DefaultQueuePriority = 1
+	QueuesFileName       = "queues.go"
+)
+
+const (
+	SchedulerFileName = "scheduler.go"
+	SchedulesFileName = "schedules.go"
 )
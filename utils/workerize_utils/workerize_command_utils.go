@@ -10,17 +10,32 @@ import (
 	"github.com/davidh16/goblin/templates"
 	"github.com/davidh16/goblin/utils"
 	"github.com/davidh16/goblin/utils/database_utils"
+	"github.com/davidh16/goblin/utils/migration_utils"
+	"github.com/davidh16/goblin/utils/observability_utils"
+	"github.com/robfig/cron/v3"
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/printer"
 	"go/token"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 )
 
+// ScheduleData is a recurring job declared in schedules.go
+type ScheduleData struct {
+	Name            string
+	Spec            string
+	JobTypeName     string
+	JobMetadataName string
+}
+
 type WorkerizeData struct {
 	JobsExists            bool
 	JobsOverwrite         bool
@@ -57,6 +72,10 @@ type CustomJobData struct {
 	WorkerName                string
 	ServicesToImplement       []string
 	LoggerImplemented         bool
+	Queue                     string
+	QueuePriority             int
+	MaxConcurrency            int
+	TimeoutSeconds            int
 }
 
 func InitBoilerplateWorkerizeData() *WorkerizeData {
@@ -186,9 +205,21 @@ func ImplementJobsLogic(data *WorkerizeData) error {
 		defer f.Close()
 
 		templateData := struct {
-			JobsPackage string
+			JobsPackage            string
+			LoggerImplemented      bool
+			LoggerPackage          string
+			LoggerPackageImport    string
+			TracingImplemented     bool
+			TelemetryPackage       string
+			TelemetryPackageImport string
 		}{
-			JobsPackage: strings.Split(cli_config.CliConfig.JobsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.JobsFolderPath, "/"))-1],
+			JobsPackage:            strings.Split(cli_config.CliConfig.JobsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.JobsFolderPath, "/"))-1],
+			LoggerImplemented:      data.LoggerImplemented,
+			LoggerPackage:          strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
+			LoggerPackageImport:    path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.LoggerFolderPath),
+			TracingImplemented:     observability_utils.TracingImplemented(),
+			TelemetryPackage:       observability_utils.TelemetryPackage(),
+			TelemetryPackageImport: observability_utils.TelemetryPackageImport(),
 		}
 
 		err = tmpl.Execute(f, templateData)
@@ -198,8 +229,75 @@ func ImplementJobsLogic(data *WorkerizeData) error {
 
 		fmt.Println("✅ Jobs manager logic generated successfully.")
 
+		// the failed jobs admin uses the methods of the jobs manager, so it is generated with it
+		adminTmpl, err := template.ParseFS(templates.Files, JobsAdminTemplateFilePath)
+		if err != nil {
+			return err
+		}
+
+		adminFile, err := os.Create(path.Join(cli_config.CliConfig.JobsFolderPath, JobsAdminFileName))
+		if err != nil {
+			return err
+		}
+		defer adminFile.Close()
+
+		err = adminTmpl.Execute(adminFile, templateData)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("✅ Failed jobs admin generated successfully.")
 	}
 
+	// failed jobs are saved to the jobs table of the database main.go passes to the jobs manager
+	err := migration_utils.GenerateTemplatedMigrationFilesWithData(JobsMigrationName, JobsMigrationUpTemplatePath, JobsMigrationDownTemplatePath, struct {
+		MariaDB bool
+	}{
+		MariaDB: database_utils.JobsDatabaseIsMariaDB(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the jobs manager polls the queues declared in queues.go, the file holds the queues chosen by custom jobs, so it is never overwritten
+	return GenerateQueuesFile()
+}
+
+// GenerateQueuesFile generates queues.go with the default queue only, if it doesn't exist yet
+func GenerateQueuesFile() error {
+	queuesPath := path.Join(cli_config.CliConfig.JobsFolderPath, QueuesFileName)
+	if utils.FileExists(queuesPath) {
+		return nil
+	}
+
+	tmpl, err := template.ParseFS(templates.Files, QueuesTemplateFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(cli_config.CliConfig.JobsFolderPath, 0755) // 0755 = rwxr-xr-x
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(queuesPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	templateData := struct {
+		JobsPackage string
+	}{
+		JobsPackage: strings.Split(cli_config.CliConfig.JobsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.JobsFolderPath, "/"))-1],
+	}
+
+	err = tmpl.Execute(f, templateData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Queues generated successfully.")
 	return nil
 }
 
@@ -230,11 +328,11 @@ func ImplementWorkersLogic(data *WorkerizeData) error {
 
 		templateData := struct {
 			WorkersPackage    string
-			JobsPackage       string
+			JobsPackageImport string
 			LoggerImplemented bool
 		}{
 			WorkersPackage:    strings.Split(cli_config.CliConfig.WorkersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.WorkersFolderPath, "/"))-1],
-			JobsPackage:       cli_config.CliConfig.JobsFolderPath,
+			JobsPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.JobsFolderPath),
 			LoggerImplemented: data.LoggerImplemented,
 		}
 
@@ -270,17 +368,26 @@ func ImplementWorkersLogic(data *WorkerizeData) error {
 		defer f.Close()
 
 		templateData := struct {
-			WorkersPackage    string
-			JobsImport        string
-			LoggerImport      string
-			LoggerPackage     string
-			LoggerImplemented bool
+			WorkersPackage         string
+			JobsPackageImport      string
+			ServicesPackageImport  string
+			LoggerPackageImport    string
+			LoggerPackage          string
+			LoggerImplemented      bool
+			MetricsImplemented     bool
+			TelemetryPackage       string
+			TelemetryPackageImport string
 		}{
-			WorkersPackage:    strings.Split(cli_config.CliConfig.WorkersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.WorkersFolderPath, "/"))-1],
-			JobsImport:        cli_config.CliConfig.JobsFolderPath,
-			LoggerImport:      cli_config.CliConfig.LoggerFolderPath,
-			LoggerPackage:     strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
-			LoggerImplemented: data.LoggerImplemented,
+			WorkersPackage:        strings.Split(cli_config.CliConfig.WorkersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.WorkersFolderPath, "/"))-1],
+			JobsPackageImport:     path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.JobsFolderPath),
+			ServicesPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.ServicesFolderPath),
+			LoggerPackageImport:   path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.LoggerFolderPath),
+			LoggerPackage:         strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
+			LoggerImplemented:     data.LoggerImplemented,
+			// jobs processed, failed and retried are counted once the MetricsMiddleware has been generated
+			MetricsImplemented:     observability_utils.MetricsImplemented(),
+			TelemetryPackage:       observability_utils.TelemetryPackage(),
+			TelemetryPackageImport: observability_utils.TelemetryPackageImport(),
 		}
 
 		err = tmpl.Execute(f, templateData)
@@ -638,11 +745,18 @@ func GenerateCustomWorkerPool(customJobData *CustomJobData) error {
 		LoggerPackage         string
 		WorkerPoolName        string
 		WorkerName            string
+		JobTypeName           string
 		WorkerPoolSize        int
 		NumberOfRetries       int
+		MaxConcurrency        int
+		TimeoutSeconds        int
 		CustomJobMetadataName string
 		ServicesToImplement   []string
 		LoggerImplemented     bool
+
+		TracingImplemented     bool
+		TelemetryPackage       string
+		TelemetryPackageImport string
 	}{
 		WorkersPackage:        strings.Split(cli_config.CliConfig.WorkersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.WorkersFolderPath, "/"))-1],
 		JobsPackageImport:     path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.JobsFolderPath),
@@ -652,11 +766,18 @@ func GenerateCustomWorkerPool(customJobData *CustomJobData) error {
 		LoggerPackage:         strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
 		WorkerPoolName:        customJobData.WorkerPoolNamePascalCase,
 		WorkerName:            customJobData.WorkerName,
+		JobTypeName:           customJobData.JobTypeName,
 		WorkerPoolSize:        customJobData.WorkerPoolSize,
 		NumberOfRetries:       customJobData.WorkerPoolNumberOfRetries,
+		MaxConcurrency:        customJobData.MaxConcurrency,
+		TimeoutSeconds:        customJobData.TimeoutSeconds,
 		CustomJobMetadataName: customJobData.JobMetadataName,
 		ServicesToImplement:   customJobData.ServicesToImplement,
 		LoggerImplemented:     customJobData.LoggerImplemented,
+
+		TracingImplemented:     observability_utils.TracingImplemented(),
+		TelemetryPackage:       observability_utils.TelemetryPackage(),
+		TelemetryPackageImport: observability_utils.TelemetryPackageImport(),
 	}
 
 	err = tmpl.Execute(f, templateData)
@@ -691,3 +812,455 @@ func GenerateWorkerStructFields(services []string) string {
 	}
 	return builder.String()
 }
+
+// AddTraceContextToJob adds the TraceContext field to the Job struct of a job.go generated before goblin observability,
+// the jobs manager regenerated with tracing stores the trace context of the enqueuing span in it
+func AddTraceContextToJob() error {
+	baseJobFilePath := path.Join(cli_config.CliConfig.JobsFolderPath, "job.go")
+
+	content, err := os.ReadFile(baseJobFilePath)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, baseJobFilePath, content, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	var jobStruct *ast.StructType
+	ast.Inspect(node, func(n ast.Node) bool {
+		typeSpec, ok := n.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != "Job" {
+			return true
+		}
+		jobStruct, _ = typeSpec.Type.(*ast.StructType)
+		return false
+	})
+	if jobStruct == nil {
+		return errors.New("Job struct not found in " + baseJobFilePath)
+	}
+
+	for _, field := range jobStruct.Fields.List {
+		for _, name := range field.Names {
+			if name.Name == "TraceContext" {
+				return nil
+			}
+		}
+	}
+
+	// the field is inserted as text, so comments of the existing fields stay where they are
+	closing := fset.Position(jobStruct.Fields.Closing).Offset
+	traceContextField := "TraceContext map[string]string `gorm:\"serializer:json\" json:\"trace_context,omitempty\"` // W3C trace context of the span which enqueued the job, continued by workers handling it\n"
+
+	updated := append([]byte{}, content[:closing]...)
+	updated = append(updated, traceContextField...)
+	updated = append(updated, content[closing:]...)
+
+	formatted, err := format.Source(updated)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(baseJobFilePath, formatted, 0644)
+}
+
+// ListQueues returns the names of the queues declared in queuePriorities of queues.go, with their priorities
+func ListQueues() (map[string]int, error) {
+	queuesPath := path.Join(cli_config.CliConfig.JobsFolderPath, QueuesFileName)
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, queuesPath, nil, parser.AllErrors)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]int{DefaultQueue: DefaultQueuePriority}, nil
+		}
+		return nil, err
+	}
+
+	queues := make(map[string]int)
+	queuePriorities := findVarLiteral(node, "queuePriorities")
+	if queuePriorities == nil {
+		return nil, errors.New("queuePriorities not found in " + queuesPath)
+	}
+
+	for _, elt := range queuePriorities.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		queue, ok := queueName(kv.Key)
+		if !ok {
+			continue
+		}
+		priority := DefaultQueuePriority
+		if lit, ok := kv.Value.(*ast.BasicLit); ok && lit.Kind == token.INT {
+			priority, _ = strconv.Atoi(lit.Value)
+		}
+		queues[queue] = priority
+	}
+
+	return queues, nil
+}
+
+// AddCustomJobToQueue maps the job type of the custom job to its queue in queues.go and declares the queue with its priority if it is new.
+// The entries are inserted as text, so the comments of queues.go stay where they are.
+func AddCustomJobToQueue(customJobData *CustomJobData) error {
+	if err := GenerateQueuesFile(); err != nil {
+		return err
+	}
+
+	queuesPath := path.Join(cli_config.CliConfig.JobsFolderPath, QueuesFileName)
+
+	content, err := os.ReadFile(queuesPath)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, queuesPath, content, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	queuePriorities := findVarLiteral(node, "queuePriorities")
+	jobTypeQueueMap := findVarLiteral(node, "jobTypeQueueMap")
+	if queuePriorities == nil || jobTypeQueueMap == nil {
+		return errors.New("queuePriorities or jobTypeQueueMap not found in " + queuesPath)
+	}
+
+	// edits are collected with their offsets and applied from the end of the file, so the offsets of the others stay valid
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+
+	queueDeclared := false
+	for _, elt := range queuePriorities.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if queue, ok := queueName(kv.Key); ok && queue == customJobData.Queue {
+				queueDeclared = true
+			}
+		}
+	}
+	if !queueDeclared {
+		closing := fset.Position(queuePriorities.Rbrace).Offset
+		edits = append(edits, edit{closing, closing, fmt.Sprintf("%q: %d,\n", customJobData.Queue, customJobData.QueuePriority)})
+	}
+
+	queueValue := strconv.Quote(customJobData.Queue)
+	if customJobData.Queue == DefaultQueue {
+		queueValue = "DefaultQueue"
+	}
+
+	jobTypeMapped := false
+	for _, elt := range jobTypeQueueMap.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if keyIdent, ok := kv.Key.(*ast.Ident); ok && keyIdent.Name == customJobData.JobTypeName {
+			// the job type moves to the queue chosen now
+			edits = append(edits, edit{fset.Position(kv.Value.Pos()).Offset, fset.Position(kv.Value.End()).Offset, queueValue})
+			jobTypeMapped = true
+		}
+	}
+	if !jobTypeMapped {
+		closing := fset.Position(jobTypeQueueMap.Rbrace).Offset
+		edits = append(edits, edit{closing, closing, fmt.Sprintf("%s: %s,\n", customJobData.JobTypeName, queueValue)})
+	}
+
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+
+	updated := append([]byte{}, content...)
+	for _, e := range edits {
+		updated = append(updated[:e.start], append([]byte(e.text), updated[e.end:]...)...)
+	}
+
+	formatted, err := format.Source(updated)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(queuesPath, formatted, 0644); err != nil {
+		return err
+	}
+
+	fmt.Println(fmt.Sprintf("✅ %s is enqueued to the %s queue.", customJobData.JobTypeName, customJobData.Queue))
+	return nil
+}
+
+// findVarLiteral returns the composite literal the package level variable is initialized with
+func findVarLiteral(node *ast.File, name string) *ast.CompositeLit {
+	var lit *ast.CompositeLit
+	ast.Inspect(node, func(n ast.Node) bool {
+		valSpec, ok := n.(*ast.ValueSpec)
+		if !ok || len(valSpec.Names) == 0 || len(valSpec.Values) == 0 || valSpec.Names[0].Name != name {
+			return lit == nil
+		}
+		lit, _ = valSpec.Values[0].(*ast.CompositeLit)
+		return false
+	})
+	return lit
+}
+
+// queueName returns the name of the queue a key of queuePriorities or a value of jobTypeQueueMap stands for
+func queueName(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if e.Name == "DefaultQueue" {
+			return DefaultQueue, true
+		}
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			queue, err := strconv.Unquote(e.Value)
+			return queue, err == nil
+		}
+	}
+	return "", false
+}
+
+// JobsAdminImplemented reports whether the failed jobs admin run by goblin jobs has been generated
+func JobsAdminImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.JobsFolderPath, JobsAdminFileName))
+}
+
+// SchedulerImplemented reports whether the scheduler enqueueing the recurring jobs has been generated
+func SchedulerImplemented() bool {
+	return utils.FileExists(path.Join(cli_config.CliConfig.WorkersFolderPath, SchedulerFileName))
+}
+
+// GenerateScheduler generates scheduler.go, and schedules.go with no schedules if it doesn't exist yet,
+// schedules.go holds the schedules declared by the project, so it is never overwritten
+func GenerateScheduler(loggerImplemented bool) error {
+	err := os.MkdirAll(cli_config.CliConfig.WorkersFolderPath, 0755) // 0755 = rwxr-xr-x
+	if err != nil {
+		return err
+	}
+
+	templateData := struct {
+		WorkersPackage      string
+		JobsPackageImport   string
+		LoggerImplemented   bool
+		LoggerPackage       string
+		LoggerPackageImport string
+	}{
+		WorkersPackage:      strings.Split(cli_config.CliConfig.WorkersFolderPath, "/")[len(strings.Split(cli_config.CliConfig.WorkersFolderPath, "/"))-1],
+		JobsPackageImport:   path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.JobsFolderPath),
+		LoggerImplemented:   loggerImplemented,
+		LoggerPackage:       strings.Split(cli_config.CliConfig.LoggerFolderPath, "/")[len(strings.Split(cli_config.CliConfig.LoggerFolderPath, "/"))-1],
+		LoggerPackageImport: path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.LoggerFolderPath),
+	}
+
+	files := []struct {
+		templatePath string
+		fileName     string
+	}{
+		{SchedulerTemplateFilePath, SchedulerFileName},
+		{SchedulesTemplateFilePath, SchedulesFileName},
+	}
+
+	for _, file := range files {
+		filePath := path.Join(cli_config.CliConfig.WorkersFolderPath, file.fileName)
+		if file.fileName == SchedulesFileName && utils.FileExists(filePath) {
+			continue
+		}
+
+		tmpl, err := template.ParseFS(templates.Files, file.templatePath)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(filePath)
+		if err != nil {
+			return err
+		}
+
+		err = tmpl.Execute(f, templateData)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(fmt.Sprintf("✅ %s generated successfully.", file.fileName))
+	}
+
+	return nil
+}
+
+// ValidateCronSpec parses the cron expression the way the generated scheduler does and returns its next tick
+func ValidateCronSpec(spec string) (time.Time, error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(time.Now()), nil
+}
+
+// ListJobTypes returns the job types registered in jobTypeMetadataMap of job.go, mapped to the name of their metadata type
+func ListJobTypes() (map[string]string, error) {
+	baseJobFilePath := path.Join(cli_config.CliConfig.JobsFolderPath, "job.go")
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, baseJobFilePath, nil, parser.AllErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	jobTypeMetadataMap := findVarLiteral(node, "jobTypeMetadataMap")
+	if jobTypeMetadataMap == nil {
+		return nil, errors.New("jobTypeMetadataMap not found in " + baseJobFilePath)
+	}
+
+	jobTypes := make(map[string]string)
+	for _, elt := range jobTypeMetadataMap.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		keyIdent, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		// the value is reflect.TypeOf(XJobMetadata{})
+		call, ok := kv.Value.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			continue
+		}
+		metadata, ok := call.Args[0].(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		if metadataIdent, ok := metadata.Type.(*ast.Ident); ok {
+			jobTypes[keyIdent.Name] = metadataIdent.Name
+		}
+	}
+
+	return jobTypes, nil
+}
+
+// ListSchedules returns the schedules declared in schedules.go, a schedule whose fields aren't literals is listed with the fields which are
+func ListSchedules() ([]ScheduleData, error) {
+	schedulesPath := path.Join(cli_config.CliConfig.WorkersFolderPath, SchedulesFileName)
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, schedulesPath, nil, parser.AllErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	schedulesLit := findVarLiteral(node, "schedules")
+	if schedulesLit == nil {
+		return nil, errors.New("schedules not found in " + schedulesPath)
+	}
+
+	var schedules []ScheduleData
+	for _, elt := range schedulesLit.Elts {
+		scheduleLit, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+
+		var schedule ScheduleData
+		for _, field := range scheduleLit.Elts {
+			kv, ok := field.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			fieldIdent, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			switch fieldIdent.Name {
+			case "Name", "Spec":
+				lit, ok := kv.Value.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					continue
+				}
+				if fieldIdent.Name == "Name" {
+					schedule.Name = value
+				} else {
+					schedule.Spec = value
+				}
+			case "JobType":
+				if selector, ok := kv.Value.(*ast.SelectorExpr); ok {
+					schedule.JobTypeName = selector.Sel.Name
+				}
+			case "Metadata":
+				if metadata, ok := kv.Value.(*ast.CompositeLit); ok {
+					if selector, ok := metadata.Type.(*ast.SelectorExpr); ok {
+						schedule.JobMetadataName = selector.Sel.Name
+					}
+				}
+			}
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// AddSchedule appends the schedule to schedules in schedules.go, it is inserted as text, so the comments of the file stay where they are
+func AddSchedule(schedule *ScheduleData) error {
+	schedulesPath := path.Join(cli_config.CliConfig.WorkersFolderPath, SchedulesFileName)
+
+	content, err := os.ReadFile(schedulesPath)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, schedulesPath, content, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	schedulesLit := findVarLiteral(node, "schedules")
+	if schedulesLit == nil {
+		return errors.New("schedules not found in " + schedulesPath)
+	}
+
+	jobsPackageImport := path.Join(cli_config.CliConfig.ProjectName, cli_config.CliConfig.JobsFolderPath)
+	jobsPackage := strings.Split(cli_config.CliConfig.JobsFolderPath, "/")[len(strings.Split(cli_config.CliConfig.JobsFolderPath, "/"))-1]
+
+	closing := fset.Position(schedulesLit.Rbrace).Offset
+	scheduleLit := fmt.Sprintf("{\nName: %q,\nSpec: %q,\nJobType: %s.%s,\nMetadata: %s.%s{},\n},\n",
+		schedule.Name, schedule.Spec, jobsPackage, schedule.JobTypeName, jobsPackage, schedule.JobMetadataName)
+
+	updated := append([]byte{}, content[:closing]...)
+	updated = append(updated, scheduleLit...)
+	updated = append(updated, content[closing:]...)
+
+	jobsImported := false
+	for _, importSpec := range node.Imports {
+		if importPath, err := strconv.Unquote(importSpec.Path.Value); err == nil && importPath == jobsPackageImport {
+			jobsImported = true
+		}
+	}
+	if !jobsImported {
+		// the import is added after the package clause, format.Source keeps it apart from an existing import block
+		packageEnd := fset.Position(node.Name.End()).Offset
+		updated = append(append(append([]byte{}, updated[:packageEnd]...), fmt.Sprintf("\n\nimport %q", jobsPackageImport)...), updated[packageEnd:]...)
+	}
+
+	formatted, err := format.Source(updated)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(schedulesPath, formatted, 0644); err != nil {
+		return err
+	}
+
+	fmt.Println(fmt.Sprintf("✅ Schedule %s added to %s.", schedule.Name, SchedulesFileName))
+	return nil
+}
@@ -0,0 +1,163 @@
+package workerize_utils
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/davidh16/goblin/cli_config"
+	"github.com/davidh16/goblin/utils/logger_utils"
+)
+
+// testProjectName is the module of the project generated by the tests
+const testProjectName = "example.com/app"
+
+// useTestProject points the config at an empty module in a temporary folder and makes it the working directory
+func useTestProject(t *testing.T) string {
+	t.Helper()
+
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+
+	// the logger is written against go-gelf of the master branch, the latest commits of the module are the ones of the v2 branch
+	goMod := "module " + testProjectName + "\n\ngo 1.24\n\nrequire github.com/Graylog2/go-gelf v0.0.0-20170811154226-7ebf4f536d8f\n"
+	if err := os.WriteFile("go.mod", []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	previousConfig := cli_config.CliConfig
+	t.Cleanup(func() { cli_config.CliConfig = previousConfig })
+	cli_config.CliConfig = &cli_config.Config{
+		ProjectName:          testProjectName,
+		ServicesFolderPath:   "services",
+		WorkersFolderPath:    "workers",
+		JobsFolderPath:       "jobs",
+		LoggerFolderPath:     "logger",
+		MigrationsFolderPath: "migrations",
+		TelemetryFolderPath:  "telemetry",
+		ConfigFolderPath:     "config",
+	}
+
+	// the orchestrator and the worker pools only need the type of the central service
+	if err := os.MkdirAll("services", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join("services", "central_service.go"), []byte("package services\n\ntype CentralService struct{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return projectDir
+}
+
+// runGo runs the go command in the generated project and fails the test with its output
+func runGo(t *testing.T, projectDir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = projectDir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go %s: %s\n%s", strings.Join(args, " "), err, output)
+	}
+}
+
+// TestGeneratedWorkersBuild generates the jobs manager, the orchestrator, a custom worker pool and the scheduler
+// with and without the logger, builds and vets the project and runs the tests of testdata/jobs against it.
+// The dependencies of the generated project are downloaded, so it runs as an integration test only, with GOBLIN_INTEGRATION set.
+func TestGeneratedWorkersBuild(t *testing.T) {
+	if os.Getenv("GOBLIN_INTEGRATION") == "" {
+		t.Skip("generating and building a project downloads its dependencies, set GOBLIN_INTEGRATION=1 to run it")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+
+	fixture, err := os.ReadFile(path.Join("testdata", "jobs", "jobs_manager_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		name              string
+		loggerImplemented bool
+		timeoutSeconds    int
+	}{
+		{name: "without logger", loggerImplemented: false, timeoutSeconds: 0},
+		{name: "with logger", loggerImplemented: true, timeoutSeconds: 30},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			projectDir := useTestProject(t)
+
+			if testCase.loggerImplemented {
+				if err := logger_utils.GenerateLogger(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			data := &WorkerizeData{
+				JobsOverwrite:         true,
+				JobsManagerOverwrite:  true,
+				OrchestratorOverwrite: true,
+				WorkerPoolOverwrite:   true,
+				LoggerImplemented:     testCase.loggerImplemented,
+			}
+			if err := ImplementJobsLogic(data); err != nil {
+				t.Fatal(err)
+			}
+			if err := ImplementWorkersLogic(data); err != nil {
+				t.Fatal(err)
+			}
+
+			customJobData := &CustomJobData{
+				JobTypeName:               "JobTypeSendEmail",
+				JobMetadataName:           "SendEmailJobMetadata",
+				JobMetadataFileName:       "send_email_job_metadata.go",
+				WorkerPoolNamePascalCase:  "SendEmailWorkerPool",
+				WorkerPoolFileName:        "send_email_worker_pool.go",
+				WorkerName:                "SendEmailWorker",
+				WorkerPoolSize:            4,
+				WorkerPoolNumberOfRetries: 3,
+				MaxConcurrency:            2,
+				TimeoutSeconds:            testCase.timeoutSeconds,
+				LoggerImplemented:         testCase.loggerImplemented,
+				Queue:                     "critical",
+				QueuePriority:             3,
+			}
+			if err := GenerateCustomJobMetadataFile(customJobData); err != nil {
+				t.Fatal(err)
+			}
+			if err := AddCustomJobToBaseJob(customJobData); err != nil {
+				t.Fatal(err)
+			}
+			if err := AddCustomJobToQueue(customJobData); err != nil {
+				t.Fatal(err)
+			}
+			if err := GenerateCustomWorkerPool(customJobData); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := GenerateScheduler(testCase.loggerImplemented); err != nil {
+				t.Fatal(err)
+			}
+			if err := AddSchedule(&ScheduleData{
+				Name:            "nightly_email",
+				Spec:            "0 3 * * *",
+				JobTypeName:     customJobData.JobTypeName,
+				JobMetadataName: customJobData.JobMetadataName,
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(path.Join(projectDir, "jobs", "jobs_manager_test.go"), fixture, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			runGo(t, projectDir, "mod", "tidy")
+			runGo(t, projectDir, "build", "./...")
+			runGo(t, projectDir, "vet", "./...")
+			runGo(t, projectDir, "test", "./jobs/")
+		})
+	}
+}